@@ -1,56 +1,294 @@
 package dotnotation
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
-// Accessor provides two methods, Get and Set, that can be configured to handle custom data structures via the
-// exported properties, Parser, Getter, and Setter.
+// Accessor provides methods such as Get, Set, and Delete, that can be configured to handle custom data structures via
+// the exported properties, Parser, Getter, Setter, and Deleter.
 type Accessor struct {
 	// Getter returns the property value of a given target, or an error.
 	Getter func(target interface{}, property string) (interface{}, error)
+	// GetterContext is used by GetContext instead of Getter, if set, and may perform I/O, honouring the context.
+	GetterContext func(ctx context.Context, target interface{}, property string) (interface{}, error)
 	// Setter sets the property value of a given target, to a given value, or returns an error.
 	Setter func(target interface{}, property string, value interface{}) error
+	// Deleter removes the property from a given target, or returns an error.
+	Deleter func(target interface{}, property string) error
 	// Parser converts a given key into a list of properties to access in order to get or set.
 	Parser func(key string) []string
+	// MaxPadding is the maximum number of nil values that Set may insert, in order to set an index beyond the length
+	// of a slice, the default of zero only allows appending, by setting the index equal to the length.
+	MaxPadding int
+	// FollowRefs enables following JSON references, like {"$ref": "#/definitions/Foo"}, within Get, where the part
+	// after the "#" is a JSON pointer, and the part before identifies another document, loaded using ResolveRef.
+	FollowRefs bool
+	// ResolveRef returns the document identified by uri, for FollowRefs, if nil only local references are supported.
+	ResolveRef func(uri string) (interface{}, error)
+	// CacheRawJSON enables replacing json.RawMessage values, in their parent, with their decoded value, when they are
+	// traversed by Get, so that they are only decoded once, which means Get modifies the target. They are always
+	// replaced when traversed in order to modify them, e.g. by Set. It's ignored by Document and Snapshot.
+	CacheRawJSON bool
 }
 
+// Set sets the value at key, within target. Slices found at any depth may be grown by setting the index equal to
+// their length (or greater, up to MaxPadding), the grown slice will be written back into it's parent, though a slice
+// passed directly as target must be a pointer (*[]interface{}) to be grown.
 func (p Accessor) Set(target interface{}, key string, value interface{}) error {
 	properties := p.parser(key)
 
-	for i, property := range properties {
-		if i == (len(properties) - 1) {
-			// we reached the last property
-			return p.setter(target, property, value)
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return p.set(target, properties, value)
+}
+
+// Delete removes the value at key, within target. Deleting from a slice shifts any following elements to the left,
+// and writes the shrunk slice back into it's parent, though a slice passed directly as target must be a pointer
+// (*[]interface{}).
+func (p Accessor) Delete(target interface{}, key string) error {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return p.delete(target, properties)
+}
+
+// set implements Set, for a non-empty list of properties.
+func (p Accessor) set(target interface{}, properties []string, value interface{}) error {
+	// attempt to get each level before the last property, so we can set the last property
+	values, err := p.walk(target, properties[:len(properties)-1], true)
+	if err != nil {
+		return err
+	}
+
+	property := properties[len(properties)-1]
+
+	switch v := values[len(values)-1].(type) {
+	case []interface{}:
+		if i, err := ParseIndex(property); err != nil || i < len(v) || len(values) == 1 {
+			break
 		}
 
-		// attempt to get the next level, so we can set the last property
-		var err error
-		target, err = p.getter(target, property)
-		if err != nil {
+		// slices are passed by value, so grow a copy, then write it back into the parent
+		return p.updateSlice(values, properties[:len(properties)-1], func(slice []interface{}) ([]interface{}, error) {
+			err := p.setSlice(&slice, property, value)
+			return slice, err
+		})
+
+	case *[]interface{}:
+		return p.setSlice(v, property, value)
+	}
+
+	return p.setter(values[len(values)-1], property, value)
+}
+
+// create implements Set, for a non-empty list of properties, first setting any missing intermediate values to an
+// empty map[string]interface{}.
+func (p Accessor) create(target interface{}, properties []string, value interface{}) error {
+	for i := 1; i < len(properties); i++ {
+		if _, err := p.get(target, properties[:i]); err == nil {
+			continue
+		}
+
+		if err := p.set(target, properties[:i], map[string]interface{}{}); err != nil {
 			return err
 		}
 	}
 
-	return errors.New("no properties parsed from key: " + key)
+	return p.set(target, properties, value)
+}
+
+// delete implements Delete, for a non-empty list of properties.
+func (p Accessor) delete(target interface{}, properties []string) error {
+	values, err := p.walk(target, properties[:len(properties)-1], true)
+	if err != nil {
+		return err
+	}
+
+	property := properties[len(properties)-1]
+
+	if _, ok := values[len(values)-1].([]interface{}); ok && len(values) > 1 {
+		// slices are passed by value, so shrink a copy, then write it back into the parent
+		return p.updateSlice(values, properties[:len(properties)-1], func(slice []interface{}) ([]interface{}, error) {
+			err := p.deleter(&slice, property)
+			return slice, err
+		})
+	}
+
+	return p.deleter(values[len(values)-1], property)
+}
+
+// Insert inserts value into a slice, at the index given by the last property of key, shifting any elements at or after
+// that index to the right. The index may be equal to the length of the slice, to append. The resized slice will be
+// written back into it's parent, though a slice passed directly as target must be a pointer (*[]interface{}).
+func (p Accessor) Insert(target interface{}, key string, value interface{}) error {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return p.insert(target, properties, value)
+}
+
+// insert implements Insert, for a non-empty list of properties.
+func (p Accessor) insert(target interface{}, properties []string, value interface{}) error {
+	values, err := p.walk(target, properties[:len(properties)-1], true)
+	if err != nil {
+		return err
+	}
+
+	property := properties[len(properties)-1]
+
+	return p.updateSlice(values, properties[:len(properties)-1], func(slice []interface{}) ([]interface{}, error) {
+		i, err := ParseIndex(property)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot insert non-integer property '%s' on a slice", property)
+		}
+
+		if i < 0 || i > len(slice) {
+			return nil, fmt.Errorf("cannot insert out of range property '%s' on a slice", property)
+		}
+
+		result := make([]interface{}, 0, len(slice)+1)
+		result = append(result, slice[:i]...)
+		result = append(result, value)
+		return append(result, slice[i:]...), nil
+	})
+}
+
+// Append appends values to the slice at key, within target, writing the grown slice back into it's parent.
+func (p Accessor) Append(target interface{}, key string, values ...interface{}) error {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	path, err := p.walk(target, properties, true)
+	if err != nil {
+		return err
+	}
+
+	return p.updateSlice(path, properties, func(slice []interface{}) ([]interface{}, error) {
+		return append(slice[:len(slice):len(slice)], values...), nil
+	})
 }
 
+// Get returns the value at key, within target, following JSON references if FollowRefs is enabled.
 func (p Accessor) Get(target interface{}, key string) (interface{}, error) {
 	properties := p.parser(key)
 
-	for i, property := range properties {
-		if i == (len(properties) - 1) {
-			// we reached the last property
-			return p.getter(target, property)
+	if len(properties) == 0 {
+		return nil, errors.New("no properties parsed from key: " + key)
+	}
+
+	if p.FollowRefs {
+		return p.getRefs(target, properties)
+	}
+
+	return p.get(target, properties)
+}
+
+// get implements Get, for a non-empty list of properties.
+func (p Accessor) get(target interface{}, properties []string) (interface{}, error) {
+	for _, property := range properties {
+		value, err := p.getter(target, property)
+		if err != nil {
+			return nil, err
 		}
 
-		// attempt to get the next level
-		var err error
-		target, err = p.getter(target, property)
+		if p.CacheRawJSON {
+			if value, err = p.decodeRaw(target, property, value); err != nil {
+				return nil, err
+			}
+		}
+
+		target = value
+	}
+
+	return target, nil
+}
+
+// walk returns target followed by the value of each property, each accessed on the previous value. If decode is
+// true, or CacheRawJSON is enabled, any json.RawMessage values are replaced in their parent by their decoded value,
+// which is necessary in order to modify them.
+func (p Accessor) walk(target interface{}, properties []string, decode bool) ([]interface{}, error) {
+	values := make([]interface{}, 1, len(properties)+1)
+	values[0] = target
+
+	for _, property := range properties {
+		value, err := p.getter(target, property)
 		if err != nil {
 			return nil, err
 		}
+
+		if decode || p.CacheRawJSON {
+			if value, err = p.decodeRaw(target, property, value); err != nil {
+				return nil, err
+			}
+		}
+
+		target = value
+		values = append(values, target)
 	}
 
-	return nil, errors.New("no properties parsed from key: " + key)
+	return values, nil
+}
+
+// updateSlice replaces the slice at the end of values, as returned by walk for properties, with the result of fn,
+// writing it back into the parent container, or through the pointer, in the case of *[]interface{}.
+func (p Accessor) updateSlice(values []interface{}, properties []string, fn func(slice []interface{}) ([]interface{}, error)) error {
+	switch v := values[len(values)-1].(type) {
+	case *[]interface{}:
+		slice, err := fn(*v)
+		if err != nil {
+			return err
+		}
+
+		*v = slice
+		return nil
+
+	case []interface{}:
+		if len(values) == 1 {
+			return errors.New("cannot resize a slice without a parent, a pointer (*[]interface{}) must be used")
+		}
+
+		slice, err := fn(v)
+		if err != nil {
+			return err
+		}
+
+		return p.setter(values[len(values)-2], properties[len(properties)-1], slice)
+
+	default:
+		return fmt.Errorf("cannot resize type %T as a slice", v)
+	}
+}
+
+// setSlice calls the setter with a pointer to a copy of the target slice, padded with nil values if the property is
+// an index greater than the length, but within MaxPadding, the target is only modified on success.
+func (p Accessor) setSlice(target *[]interface{}, property string, value interface{}) error {
+	slice := *target
+
+	if i, err := ParseIndex(property); err == nil && i > len(slice) && i-len(slice) <= p.MaxPadding {
+		slice = append(slice[:len(slice):len(slice)], make([]interface{}, i-len(slice))...)
+	}
+
+	if err := p.setter(&slice, property, value); err != nil {
+		return err
+	}
+
+	*target = slice
+
+	return nil
 }
 
 func (p Accessor) getter(target interface{}, property string) (interface{}, error) {
@@ -69,6 +307,14 @@ func (p Accessor) setter(target interface{}, property string, value interface{})
 	return p.Setter(target, property, value)
 }
 
+func (p Accessor) deleter(target interface{}, property string) error {
+	if p.Deleter == nil {
+		return DefaultDeleter(target, property)
+	}
+
+	return p.Deleter(target, property)
+}
+
 func (p Accessor) parser(key string) []string {
 	if p.Parser == nil {
 		return DefaultParser(key)
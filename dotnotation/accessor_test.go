@@ -207,3 +207,371 @@ func TestAccessor_Set_parserError(t *testing.T) {
 		t.Fatalf("unexpected error %v", err)
 	}
 }
+
+func TestAccessor_Set_sliceGrowth(t *testing.T) {
+	testCases := []struct {
+		name     string
+		accessor Accessor
+		target   interface{}
+		key      string
+		value    interface{}
+		success  bool
+		outcome  interface{}
+	}{
+		{
+			name: "append nested in map",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.2",
+			value:   3,
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+		},
+		{
+			name: "append nested in slice",
+			target: map[string]interface{}{
+				"items": []interface{}{[]interface{}{}},
+			},
+			key:     "items.0.0",
+			value:   3,
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{[]interface{}{3}},
+			},
+		},
+		{
+			name: "beyond length without padding",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.3",
+			value:   3,
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+		},
+		{
+			name:     "beyond length with padding",
+			accessor: Accessor{MaxPadding: 2},
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.4",
+			value:   3,
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2, nil, nil, 3},
+			},
+		},
+		{
+			name:     "beyond length exceeding padding",
+			accessor: Accessor{MaxPadding: 2},
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.5",
+			value:   3,
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+		},
+		{
+			name:     "padding a root pointer",
+			accessor: Accessor{MaxPadding: 1},
+			target:   &[]interface{}{1},
+			key:      "2",
+			value:    3,
+			success:  true,
+			outcome:  &[]interface{}{1, nil, 3},
+		},
+		{
+			name:    "append to root slice",
+			target:  []interface{}{1},
+			key:     "1",
+			value:   3,
+			success: false,
+			outcome: []interface{}{1},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.accessor.Set(testCase.target, testCase.key, testCase.value)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Insert(t *testing.T) {
+	testCases := []struct {
+		name    string
+		target  interface{}
+		key     string
+		value   interface{}
+		success bool
+		outcome interface{}
+	}{
+		{
+			name: "middle",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.1",
+			value:   3,
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 3, 2},
+			},
+		},
+		{
+			name: "start",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.0",
+			value:   3,
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{3, 1, 2},
+			},
+		},
+		{
+			name: "end",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.2",
+			value:   3,
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+		},
+		{
+			name: "out of range",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.3",
+			value:   3,
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+		},
+		{
+			name: "non-integer",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+			key:     "items.one",
+			value:   3,
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+		},
+		{
+			name: "not a slice",
+			target: map[string]interface{}{
+				"items": map[string]interface{}{},
+			},
+			key:     "items.0",
+			value:   3,
+			success: false,
+			outcome: map[string]interface{}{
+				"items": map[string]interface{}{},
+			},
+		},
+		{
+			name:    "root pointer",
+			target:  &[]interface{}{1, 2},
+			key:     "0",
+			value:   3,
+			success: true,
+			outcome: &[]interface{}{3, 1, 2},
+		},
+		{
+			name:    "root slice",
+			target:  []interface{}{1, 2},
+			key:     "0",
+			value:   3,
+			success: false,
+			outcome: []interface{}{1, 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := Accessor{}.Insert(testCase.target, testCase.key, testCase.value)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Append(t *testing.T) {
+	testCases := []struct {
+		name    string
+		target  interface{}
+		key     string
+		values  []interface{}
+		success bool
+		outcome interface{}
+	}{
+		{
+			name: "nested",
+			target: map[string]interface{}{
+				"one": []interface{}{
+					map[string]interface{}{
+						"items": []interface{}{1},
+					},
+				},
+			},
+			key:     "one.0.items",
+			values:  []interface{}{2, 3},
+			success: true,
+			outcome: map[string]interface{}{
+				"one": []interface{}{
+					map[string]interface{}{
+						"items": []interface{}{1, 2, 3},
+					},
+				},
+			},
+		},
+		{
+			name: "missing",
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			key:     "items",
+			values:  []interface{}{2, 3},
+			success: false,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+		{
+			name: "not a slice",
+			target: map[string]interface{}{
+				"items": 1,
+			},
+			key:     "items",
+			values:  []interface{}{2, 3},
+			success: false,
+			outcome: map[string]interface{}{
+				"items": 1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := Accessor{}.Append(testCase.target, testCase.key, testCase.values...)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		target  interface{}
+		key     string
+		success bool
+		outcome interface{}
+	}{
+		{
+			name: "map",
+			target: map[string]interface{}{
+				"one": map[string]interface{}{"a": 1, "b": 2},
+			},
+			key:     "one.a",
+			success: true,
+			outcome: map[string]interface{}{
+				"one": map[string]interface{}{"b": 2},
+			},
+		},
+		{
+			name: "nested slice",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+			key:     "items.1",
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 3},
+			},
+		},
+		{
+			name: "nested slice out of range",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+			key:     "items.3",
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+		},
+		{
+			name:    "root pointer",
+			target:  &[]interface{}{1, 2, 3},
+			key:     "0",
+			success: true,
+			outcome: &[]interface{}{2, 3},
+		},
+		{
+			name:    "root slice",
+			target:  []interface{}{1, 2, 3},
+			key:     "0",
+			success: false,
+			outcome: []interface{}{1, 2, 3},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := Accessor{}.Delete(testCase.target, testCase.key)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Delete_parserError(t *testing.T) {
+	accessor := Accessor{
+		Parser: func(key string) []string {
+			return nil
+		},
+	}
+
+	err := accessor.Delete(nil, "key")
+
+	if "no properties parsed from key: key" != err.Error() {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,581 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TagName is the struct tag used by Bind and Unbind, formatted like `dot:"key,option,..."`, with the options
+// "required", which causes Bind to fail if the key cannot be got, "default=value", which Bind converts in the same
+// way as any other value, if the key cannot be got, and "omitempty", which causes Unbind to skip empty values, as per
+// encoding/json. Fields tagged "-" are ignored.
+const TagName = "dot"
+
+// numberType is the type of json.Number, as decoded by a json.Decoder, after calling UseNumber.
+var numberType = reflect.TypeOf(json.Number(""))
+
+// PathError describes a failure to bind or unbind the value at a single key.
+type PathError struct {
+	Key string
+	Err error
+}
+
+// BindErrors is returned by Bind and Unbind if any field failed, and lists each failure, in field order.
+type BindErrors []*PathError
+
+type fieldTag struct {
+	key        string
+	required   bool
+	omitEmpty  bool
+	hasDefault bool
+	value      string
+}
+
+func (e *PathError) Error() string {
+	return e.Key + ": " + e.Err.Error()
+}
+
+func (e BindErrors) Error() string {
+	messages := make([]string, len(e))
+
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Bind populates the tagged fields of the struct pointed to by dst, using values from src, see TagName. Values are
+// converted to the type of each field, e.g. float64 or json.Number to int, if it's integral, or string to bool, and
+// nested structs are bound using the value at their key. Untagged struct fields, including embedded structs, are
+// bound from src, and fields with keys that cannot be got are left unchanged. Every failure is returned, as
+// BindErrors.
+func (p Accessor) Bind(src interface{}, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cannot bind to type %T, expected a non-nil pointer to a struct", dst)
+	}
+
+	var errs BindErrors
+
+	p.bind(src, v.Elem(), &errs)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (p Accessor) bind(src interface{}, dst reflect.Value, errs *BindErrors) {
+	t := dst.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag, ok := parseFieldTag(field)
+		if tag.key == "-" {
+			continue
+		}
+
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				p.bind(src, dst.Field(i), errs)
+			}
+
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		value, err := p.Get(src, tag.key)
+
+		if err != nil {
+			switch {
+			case tag.required:
+				*errs = append(*errs, &PathError{Key: tag.key, Err: err})
+			case tag.hasDefault:
+				p.bindValue(tag.value, tag.key, dst.Field(i), errs)
+			}
+
+			continue
+		}
+
+		p.bindValue(value, tag.key, dst.Field(i), errs)
+	}
+}
+
+func (p Accessor) bindValue(value interface{}, key string, dst reflect.Value, errs *BindErrors) {
+	converted, err := p.convert(value, dst.Type())
+
+	if err != nil {
+		*errs = append(*errs, &PathError{Key: key, Err: err})
+		return
+	}
+
+	dst.Set(converted)
+}
+
+// Unbind sets the value of each tagged field of src, a struct or pointer to a struct, at it's key within dst, see
+// TagName. Missing intermediate values are created as map[string]interface{}, as is dst, if it's nil. Nested structs
+// with tagged fields are unbound into a new map[string]interface{}, and untagged struct fields, including embedded
+// structs, are unbound into dst. Any other values are set as-is. Every failure is returned, as BindErrors.
+func (p Accessor) Unbind(src interface{}, dst *map[string]interface{}) error {
+	v := reflect.ValueOf(src)
+
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot unbind from type %T, expected a struct or non-nil pointer to a struct", src)
+	}
+
+	if dst == nil {
+		return fmt.Errorf("cannot unbind to a nil pointer")
+	}
+
+	if *dst == nil {
+		*dst = make(map[string]interface{})
+	}
+
+	var errs BindErrors
+
+	p.unbind(v, *dst, &errs)
+
+	if len(errs) != 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func (p Accessor) unbind(src reflect.Value, dst map[string]interface{}, errs *BindErrors) {
+	t := src.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag, ok := parseFieldTag(field)
+		if tag.key == "-" {
+			continue
+		}
+
+		if !ok {
+			if field.Type.Kind() == reflect.Struct {
+				p.unbind(src.Field(i), dst, errs)
+			}
+
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		value := src.Field(i)
+
+		if tag.omitEmpty && isEmpty(value) {
+			continue
+		}
+
+		properties := p.parser(tag.key)
+
+		if len(properties) == 0 {
+			*errs = append(*errs, &PathError{Key: tag.key, Err: errors.New("no properties parsed from key")})
+			continue
+		}
+
+		if err := p.create(dst, properties, p.unbindValue(value, errs)); err != nil {
+			*errs = append(*errs, &PathError{Key: tag.key, Err: err})
+		}
+	}
+}
+
+// unbindValue returns the value to set for a field, converting structs with tagged fields to a map, and slices,
+// arrays, and maps with string keys, that contain them, to []interface{} and map[string]interface{}, as per Bind.
+// Any other value is deep copied, see Copy, so that setting the keys of other fields cannot modify src.
+func (p Accessor) unbindValue(value reflect.Value, errs *BindErrors) interface{} {
+	v := value
+
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+
+	if !hasTaggedElements(v.Type()) {
+		return deepCopy(value.Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return value.Interface()
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		result := make([]interface{}, v.Len())
+
+		for i := range result {
+			result[i] = p.unbindValue(v.Index(i), errs)
+		}
+
+		return result
+
+	case reflect.Map:
+		result := make(map[string]interface{}, v.Len())
+
+		for _, k := range v.MapKeys() {
+			result[k.String()] = p.unbindValue(v.MapIndex(k), errs)
+		}
+
+		return result
+
+	default:
+		result := make(map[string]interface{})
+
+		p.unbind(v, result, errs)
+
+		return result
+	}
+}
+
+// hasTaggedElements returns true if t is, or points to, a struct with tagged fields, as per hasTaggedFields, or a
+// slice, array, or map with string keys, of such values.
+func hasTaggedElements(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return hasTaggedFields(t)
+	case reflect.Slice, reflect.Array:
+		return hasTaggedElements(t.Elem())
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && hasTaggedElements(t.Elem())
+	default:
+		return false
+	}
+}
+
+// hasTaggedFields returns true if the struct type t has any fields tagged with TagName, including within untagged
+// struct fields.
+func hasTaggedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if _, ok := field.Tag.Lookup(TagName); ok {
+			return true
+		}
+
+		if field.Type.Kind() == reflect.Struct && hasTaggedFields(field.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmpty returns true for false, 0, a nil pointer, a nil interface value, and any empty array, slice, map, or string.
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+// convert attempts to convert value to the type t.
+func (p Accessor) convert(value interface{}, t reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(t), nil
+	}
+
+	v := reflect.ValueOf(value)
+
+	if v.Type().AssignableTo(t) {
+		return v, nil
+	}
+
+	if n, ok := value.(json.Number); ok && isNumber(t.Kind()) {
+		return convertJSONNumber(n, t)
+	}
+
+	if t == numberType && isNumber(v.Kind()) {
+		return formatJSONNumber(v), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem, err := p.convert(value, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result := reflect.New(t.Elem())
+		result.Elem().Set(elem)
+
+		return result, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Kind() == reflect.String {
+			i, err := strconv.ParseInt(v.String(), 10, t.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return reflect.ValueOf(i).Convert(t), nil
+		}
+
+		return convertNumber(v, t)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if v.Kind() == reflect.String {
+			i, err := strconv.ParseUint(v.String(), 10, t.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return reflect.ValueOf(i).Convert(t), nil
+		}
+
+		return convertNumber(v, t)
+
+	case reflect.Float32, reflect.Float64:
+		if v.Kind() == reflect.String {
+			f, err := strconv.ParseFloat(v.String(), t.Bits())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return reflect.ValueOf(f).Convert(t), nil
+		}
+
+		return convertNumber(v, t)
+
+	case reflect.Bool:
+		if v.Kind() == reflect.String {
+			b, err := strconv.ParseBool(v.String())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return reflect.ValueOf(b).Convert(t), nil
+		}
+
+	case reflect.Slice:
+		if v.Kind() != reflect.Slice {
+			break
+		}
+
+		result := reflect.MakeSlice(t, v.Len(), v.Len())
+
+		for i := 0; i < v.Len(); i++ {
+			elem, err := p.convert(v.Index(i).Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("index %d: %v", i, err)
+			}
+
+			result.Index(i).Set(elem)
+		}
+
+		return result, nil
+
+	case reflect.Array:
+		if (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Len() != t.Len() {
+			break
+		}
+
+		result := reflect.New(t).Elem()
+
+		for i := 0; i < v.Len(); i++ {
+			elem, err := p.convert(v.Index(i).Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("index %d: %v", i, err)
+			}
+
+			result.Index(i).Set(elem)
+		}
+
+		return result, nil
+
+	case reflect.Map:
+		if v.Kind() != reflect.Map {
+			break
+		}
+
+		result := reflect.MakeMapWithSize(t, v.Len())
+
+		for _, k := range v.MapKeys() {
+			key, err := p.convert(k.Interface(), t.Key())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("key %v: %v", k.Interface(), err)
+			}
+
+			elem, err := p.convert(v.MapIndex(k).Interface(), t.Elem())
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("key %v: %v", k.Interface(), err)
+			}
+
+			result.SetMapIndex(key, elem)
+		}
+
+		return result, nil
+
+	case reflect.Struct:
+		if v.Kind() <= reflect.Complex128 || v.Kind() == reflect.String {
+			break
+		}
+
+		result := reflect.New(t)
+
+		if err := p.Bind(value, result.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+
+		return result.Elem(), nil
+	}
+
+	if v.Kind() == t.Kind() && v.Type().ConvertibleTo(t) {
+		return v.Convert(t), nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("cannot convert %T to %s", value, t)
+}
+
+// convertNumber converts between numeric types, returning an error if the value would change, e.g. if it's not
+// integral, or would overflow. Conversions between float types only check for overflow, as precision is expected to
+// be lost, e.g. 0.1 cannot be represented exactly by either type.
+func convertNumber(v reflect.Value, t reflect.Type) (reflect.Value, error) {
+	if !isNumber(v.Kind()) {
+		return reflect.Value{}, fmt.Errorf("cannot convert %s to %s", v.Type(), t)
+	}
+
+	result := v.Convert(t)
+
+	if isFloat(v.Kind()) && isFloat(t.Kind()) {
+		if f := v.Float(); t.Bits() == 32 && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v to %s without loss", v.Interface(), t)
+		}
+
+		return result, nil
+	}
+
+	if result.Convert(v.Type()).Interface() != v.Interface() || isNegative(result) != isNegative(v) {
+		return reflect.Value{}, fmt.Errorf("cannot convert %v to %s without loss", v.Interface(), t)
+	}
+
+	return result, nil
+}
+
+// convertJSONNumber converts n to the numeric type t, where integer types also accept any integral value, e.g. "1e3",
+// as per convertNumber.
+func convertJSONNumber(n json.Number, t reflect.Type) (reflect.Value, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(n.String(), 10, t.Bits()); err == nil {
+			return reflect.ValueOf(i).Convert(t), nil
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if i, err := strconv.ParseUint(n.String(), 10, t.Bits()); err == nil {
+			return reflect.ValueOf(i).Convert(t), nil
+		}
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return convertNumber(reflect.ValueOf(f), t)
+}
+
+// formatJSONNumber formats the numeric value v as a json.Number.
+func formatJSONNumber(v reflect.Value) reflect.Value {
+	var s string
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s = strconv.FormatInt(v.Int(), 10)
+	case reflect.Float32, reflect.Float64:
+		s = strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	default:
+		s = strconv.FormatUint(v.Uint(), 10)
+	}
+
+	return reflect.ValueOf(json.Number(s))
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
+
+// parseFieldTag parses the TagName tag of a field, returning false if it's not present.
+func parseFieldTag(field reflect.StructField) (fieldTag, bool) {
+	value, ok := field.Tag.Lookup(TagName)
+	if !ok {
+		return fieldTag{}, false
+	}
+
+	parts := strings.Split(value, ",")
+	tag := fieldTag{key: parts[0]}
+
+	for _, option := range parts[1:] {
+		switch {
+		case option == "required":
+			tag.required = true
+		case option == "omitempty":
+			tag.omitEmpty = true
+		case strings.HasPrefix(option, "default="):
+			tag.hasDefault = true
+			tag.value = strings.TrimPrefix(option, "default=")
+		}
+	}
+
+	return tag, true
+}
@@ -0,0 +1,380 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+type bindAddress struct {
+	City     string `dot:"city"`
+	Postcode int    `dot:"postcode"`
+}
+
+type bindEmbedded struct {
+	Event string `dot:"event"`
+}
+
+type bindTarget struct {
+	bindEmbedded
+	Name     string            `dot:"payload.customer.name,required"`
+	Age      uint8             `dot:"payload.customer.age"`
+	Score    *float32          `dot:"payload.customer.score"`
+	Active   bool              `dot:"payload.customer.active,default=true"`
+	Tags     []string          `dot:"payload.customer.tags"`
+	Labels   map[string]string `dot:"payload.customer.labels"`
+	Address  bindAddress       `dot:"payload.customer.address"`
+	Raw      interface{}       `dot:"payload.customer.address"`
+	Missing  string            `dot:"payload.customer.missing"`
+	Ignored  string            `dot:"-"`
+	Untagged string
+	private  string `dot:"event"`
+}
+
+func TestBind(t *testing.T) {
+	src := map[string]interface{}{
+		"event": "created",
+		"payload": map[string]interface{}{
+			"customer": map[string]interface{}{
+				"name":   "Joe",
+				"age":    float64(30),
+				"score":  float64(1.5),
+				"tags":   []interface{}{"a", "b"},
+				"labels": map[string]interface{}{"one": "1"},
+				"address": map[string]interface{}{
+					"city":     "Brisbane",
+					"postcode": "4000",
+				},
+			},
+		},
+	}
+
+	score := float32(1.5)
+
+	expected := bindTarget{
+		bindEmbedded: bindEmbedded{Event: "created"},
+		Name:         "Joe",
+		Age:          30,
+		Score:        &score,
+		Active:       true,
+		Tags:         []string{"a", "b"},
+		Labels:       map[string]string{"one": "1"},
+		Address:      bindAddress{City: "Brisbane", Postcode: 4000},
+		Raw: map[string]interface{}{
+			"city":     "Brisbane",
+			"postcode": "4000",
+		},
+		Missing:  "unchanged",
+		Untagged: "unchanged",
+	}
+
+	actual := bindTarget{
+		Missing:  "unchanged",
+		Untagged: "unchanged",
+	}
+
+	if err := Bind(src, &actual); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}
+
+func TestBind_errors(t *testing.T) {
+	src := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"customer": map[string]interface{}{
+				"age":    float64(-1),
+				"score":  "high",
+				"active": "maybe",
+				"tags":   []interface{}{"a", 1},
+				"address": map[string]interface{}{
+					"postcode": 1.5,
+				},
+			},
+		},
+	}
+
+	var actual bindTarget
+
+	err := Bind(src, &actual)
+
+	errs, ok := err.(BindErrors)
+	if !ok {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	var keys []string
+	for _, err := range errs {
+		keys = append(keys, err.Key)
+	}
+
+	expected := []string{
+		"payload.customer.name",
+		"payload.customer.age",
+		"payload.customer.score",
+		"payload.customer.active",
+		"payload.customer.tags",
+		"payload.customer.address",
+	}
+
+	if diff := deep.Equal(expected, keys); diff != nil {
+		t.Fatalf("unexpected diff %v for error %v", strings.Join(diff, ", "), err)
+	}
+}
+
+func TestBind_invalidTarget(t *testing.T) {
+	var target bindTarget
+
+	for _, dst := range []interface{}{nil, target, (*bindTarget)(nil), new(int)} {
+		if err := Bind(map[string]interface{}{}, dst); err == nil {
+			t.Errorf("expected error for %T", dst)
+		}
+	}
+}
+
+func TestAccessor_convert(t *testing.T) {
+	testCases := []struct {
+		value   interface{}
+		target  interface{}
+		success bool
+	}{
+		{float64(1), int(1), true},
+		{float64(1.5), int(0), false},
+		{float64(256), uint8(0), false},
+		{int(-1), uint(0), false},
+		{uint64(1) << 63, int64(0), false},
+		{int(1), float64(1), true},
+		{"1", int8(1), true},
+		{"1.5", float32(1.5), true},
+		{"yes", false, false},
+		{true, "", false},
+		{"one", []byte("one"), false},
+		{json.Number("7"), int(7), true},
+		{json.Number("1e3"), uint16(1000), true},
+		{json.Number("1.5"), int(0), false},
+		{json.Number("-1"), uint(0), false},
+		{json.Number("1.5"), float64(1.5), true},
+		{json.Number("one"), float64(0), false},
+		{json.Number("9"), "9", true},
+		{int8(-3), json.Number("-3"), true},
+		{uint(3), json.Number("3"), true},
+		{float32(0.5), json.Number("0.5"), true},
+		{float64(0.1), float32(0.1), true},
+		{float64(1e39), float32(0), false},
+		{float64(-1e39), float32(0), false},
+		{float32(0.1), float64(float32(0.1)), true},
+		{json.Number("0.1"), float32(0.1), true},
+		{json.Number("1e39"), float32(0), false},
+		{"0.1", float32(0.1), true},
+	}
+
+	for _, testCase := range testCases {
+		targetType := reflect.TypeOf(testCase.target)
+
+		v, err := Accessor{}.convert(testCase.value, targetType)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("unexpected error %v converting %T %v", err, testCase.value, testCase.value)
+			continue
+		}
+
+		if err == nil && v.Interface() != testCase.target {
+			t.Errorf("unexpected value %T %v converting %T %v", v.Interface(), v.Interface(), testCase.value, testCase.value)
+		}
+	}
+}
+
+type unbindTarget struct {
+	bindEmbedded
+	Name     string       `dot:"payload.customer.name"`
+	Nickname string       `dot:"payload.customer.nickname,omitempty"`
+	Age      int          `dot:"payload.customer.age,omitempty"`
+	Address  *bindAddress `dot:"payload.customer.address"`
+	Tags     []string     `dot:"payload.customer.tags,omitempty"`
+	First    string       `dot:"payload.items.0"`
+	Ignored  string       `dot:"-"`
+	Untagged string
+}
+
+func TestUnbind(t *testing.T) {
+	src := unbindTarget{
+		bindEmbedded: bindEmbedded{Event: "created"},
+		Name:         "Joe",
+		Address:      &bindAddress{City: "Brisbane", Postcode: 4000},
+		Ignored:      "ignored",
+		Untagged:     "untagged",
+	}
+
+	expected := map[string]interface{}{
+		"event": "created",
+		"payload": map[string]interface{}{
+			"customer": map[string]interface{}{
+				"name": "Joe",
+				"address": map[string]interface{}{
+					"city":     "Brisbane",
+					"postcode": 4000,
+				},
+			},
+			"items": []interface{}{""},
+		},
+		"existing": 1,
+	}
+
+	actual := map[string]interface{}{
+		"existing": 1,
+		"payload": map[string]interface{}{
+			"items": []interface{}{},
+		},
+	}
+
+	if err := Unbind(&src, &actual); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if diff := deep.Equal(expected, actual); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	// the document is created if nil
+	var created map[string]interface{}
+
+	if err := Unbind(bindEmbedded{Event: "deleted"}, &created); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"event": "deleted"}, created); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}
+
+func TestUnbind_copies(t *testing.T) {
+	type document struct {
+		Meta  map[string]interface{} `dot:"meta"`
+		ID    int                    `dot:"meta.id"`
+		Items []interface{}          `dot:"items"`
+		First string                 `dot:"items.0"`
+	}
+
+	src := document{
+		Meta:  map[string]interface{}{"name": "one"},
+		ID:    7,
+		Items: []interface{}{"a"},
+		First: "b",
+	}
+
+	var dst map[string]interface{}
+
+	if err := Unbind(src, &dst); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"meta":  map[string]interface{}{"name": "one", "id": 7},
+		"items": []interface{}{"b"},
+	}
+
+	if diff := deep.Equal(expected, dst); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"name": "one"}, src.Meta); diff != nil {
+		t.Fatalf("expected src to be unchanged: %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal([]interface{}{"a"}, src.Items); diff != nil {
+		t.Fatalf("expected src to be unchanged: %v", strings.Join(diff, ", "))
+	}
+}
+
+func TestUnbind_errors(t *testing.T) {
+	dst := map[string]interface{}{
+		"payload": 1,
+	}
+
+	err := Unbind(unbindTarget{}, &dst)
+
+	errs, ok := err.(BindErrors)
+	if !ok || len(errs) != 3 {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	for _, src := range []interface{}{nil, 1, (*unbindTarget)(nil)} {
+		if err := Unbind(src, &dst); err == nil {
+			t.Errorf("expected error for %T", src)
+		}
+	}
+
+	if err := Unbind(unbindTarget{}, nil); err == nil {
+		t.Error("expected error for a nil pointer")
+	}
+}
+
+func TestBind_float32(t *testing.T) {
+	var dst struct {
+		F float32 `dot:"f"`
+		N float32 `dot:"n"`
+	}
+
+	if err := Bind(map[string]interface{}{"f": 0.1, "n": json.Number("0.2")}, &dst); err != nil {
+		t.Fatal(err)
+	}
+
+	if dst.F != 0.1 || dst.N != 0.2 {
+		t.Fatalf("unexpected values %v, %v", dst.F, dst.N)
+	}
+}
+
+func TestUnbind_roundTrip(t *testing.T) {
+	type document struct {
+		Addresses []bindAddress          `dot:"customer.addresses"`
+		Pointers  []*bindAddress         `dot:"customer.pointers"`
+		Fixed     [1]bindAddress         `dot:"customer.fixed"`
+		ByName    map[string]bindAddress `dot:"customer.by_name"`
+		Nested    [][]bindAddress        `dot:"customer.nested"`
+		Empty     []bindAddress          `dot:"customer.empty"`
+		Plain     map[string]int         `dot:"customer.plain"`
+	}
+
+	src := document{
+		Addresses: []bindAddress{{City: "Brisbane", Postcode: 4000}, {City: "Sydney", Postcode: 2000}},
+		Pointers:  []*bindAddress{{City: "Perth", Postcode: 6000}},
+		Fixed:     [1]bindAddress{{City: "Hobart", Postcode: 7000}},
+		ByName:    map[string]bindAddress{"home": {City: "Darwin", Postcode: 800}},
+		Nested:    [][]bindAddress{{{City: "Adelaide", Postcode: 5000}}},
+		Plain:     map[string]int{"one": 1},
+	}
+
+	var dst map[string]interface{}
+
+	if err := Unbind(src, &dst); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if value, err := Get(dst, "customer.addresses.1.city"); err != nil || value != "Sydney" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if value, err := Get(dst, "customer.by_name.home.postcode"); err != nil || value != 800 {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if value, err := Get(dst, "customer.nested.0.0.city"); err != nil || value != "Adelaide" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	var result document
+
+	if err := Bind(dst, &result); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if diff := deep.Equal(src, result); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}
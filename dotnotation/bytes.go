@@ -0,0 +1,262 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// jsonMember is the location of a member of a JSON object, or element of a JSON array, within a document, where the
+// key fields are only set for object members.
+type jsonMember struct {
+	keyStart, keyEnd     int
+	valueStart, valueEnd int
+}
+
+// GetBytes returns the raw JSON of the value at key, within the JSON document data, which is a sub-slice of data,
+// without decoding the document. The parser is used for the key, but objects and arrays are always accessed like
+// map[string]interface{} and []interface{}, respectively.
+func (p Accessor) GetBytes(data []byte, key string) (json.RawMessage, error) {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return nil, errors.New("no properties parsed from key: " + key)
+	}
+
+	if !json.Valid(data) {
+		return nil, errors.New("invalid JSON document")
+	}
+
+	start, end, err := locateJSON(data, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return data[start:end:end], nil
+}
+
+// SetBytes returns a copy of the JSON document data, with the value at key set to the JSON encoding of value, as per
+// encoding/json, without decoding the document, preserving the formatting and order of everything else. As with Set,
+// the parent of the value must exist, and new object members are added as the last member, or appended to arrays, by
+// setting the index equal to the length. New members are indented like the first member of their parent.
+func (p Accessor) SetBytes(data []byte, key string, value interface{}) ([]byte, error) {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return nil, errors.New("no properties parsed from key: " + key)
+	}
+
+	if !json.Valid(data) {
+		return nil, errors.New("invalid JSON document")
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	parentStart, parentEnd, err := locateJSON(data, properties[:len(properties)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	property := properties[len(properties)-1]
+
+	members, err := jsonMembers(data, parentStart, parentEnd, "set", property)
+	if err != nil {
+		return nil, err
+	}
+
+	if member, ok := findJSONMember(data, data[parentStart], members, property); ok {
+		return splice(data, member.valueStart, member.valueEnd, encoded), nil
+	}
+
+	var insert []byte
+
+	if len(members) != 0 {
+		first := members[0]
+
+		insert = append(insert, ',')
+
+		if data[parentStart] == '{' {
+			insert = append(insert, data[parentStart+1:first.keyStart]...)
+		} else {
+			insert = append(insert, data[parentStart+1:first.valueStart]...)
+		}
+	}
+
+	if data[parentStart] == '{' {
+		name, err := json.Marshal(property)
+		if err != nil {
+			return nil, err
+		}
+
+		insert = append(insert, name...)
+
+		if len(members) != 0 {
+			insert = append(insert, data[members[0].keyEnd:members[0].valueStart]...)
+		} else {
+			insert = append(insert, ':')
+		}
+	} else if i, err := ParseIndex(property); err != nil || i != len(members) {
+		return nil, missingJSONMember("set", data[parentStart], property)
+	}
+
+	insert = append(insert, encoded...)
+
+	// insert after the last member, or the opening delimiter
+	offset := parentStart + 1
+	if len(members) != 0 {
+		offset = members[len(members)-1].valueEnd
+	}
+
+	return splice(data, offset, offset, insert), nil
+}
+
+// locateJSON returns the start and end offsets of the value at properties, within the valid JSON document data.
+func locateJSON(data []byte, properties []string) (int, int, error) {
+	start := skipJSONSpace(data, 0)
+	end := jsonValueEnd(data, start)
+
+	for _, property := range properties {
+		members, err := jsonMembers(data, start, end, "get", property)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		member, ok := findJSONMember(data, data[start], members, property)
+		if !ok {
+			return 0, 0, missingJSONMember("get", data[start], property)
+		}
+
+		start, end = member.valueStart, member.valueEnd
+	}
+
+	return start, end, nil
+}
+
+// findJSONMember returns the member of the object or array starting with delim, matching property, where the last
+// matching object member is used, as per encoding/json.
+func findJSONMember(data []byte, delim byte, members []jsonMember, property string) (jsonMember, bool) {
+	if delim == '[' {
+		i, err := ParseIndex(property)
+
+		if err != nil || i < 0 || i >= len(members) {
+			return jsonMember{}, false
+		}
+
+		return members[i], true
+	}
+
+	for i := len(members) - 1; i >= 0; i-- {
+		var name string
+
+		// the document is valid, so the key is a valid string
+		_ = json.Unmarshal(data[members[i].keyStart:members[i].keyEnd], &name)
+
+		if name == property {
+			return members[i], true
+		}
+	}
+
+	return jsonMember{}, false
+}
+
+// missingJSONMember returns an error describing why property could not be found, for the verb "get" or "set", in the
+// object or array starting with delim, consistent with DefaultGetter and DefaultSetter.
+func missingJSONMember(verb string, delim byte, property string) error {
+	if delim == '{' {
+		return fmt.Errorf("cannot %s non-existent property '%s' on a map", verb, property)
+	}
+
+	if _, err := ParseIndex(property); err != nil {
+		return fmt.Errorf("cannot %s non-integer property '%s' on a slice", verb, property)
+	}
+
+	return fmt.Errorf("cannot %s out of range property '%s' on a slice", verb, property)
+}
+
+// jsonMembers returns the members of the object or array between start and end, within the valid JSON document data,
+// or an error including the verb and property, if it's not an object or array.
+func jsonMembers(data []byte, start int, end int, verb string, property string) ([]jsonMember, error) {
+	if data[start] != '{' && data[start] != '[' {
+		return nil, fmt.Errorf("cannot %s property '%s' on JSON value %s", verb, property, data[start:end])
+	}
+
+	var members []jsonMember
+
+	for i := skipJSONSpace(data, start+1); data[i] != '}' && data[i] != ']'; {
+		var member jsonMember
+
+		if data[start] == '{' {
+			member.keyStart = i
+			member.keyEnd = jsonValueEnd(data, i)
+			// skip the colon
+			i = skipJSONSpace(data, skipJSONSpace(data, member.keyEnd)+1)
+		}
+
+		member.valueStart = i
+		member.valueEnd = jsonValueEnd(data, i)
+		members = append(members, member)
+
+		i = skipJSONSpace(data, member.valueEnd)
+		if data[i] == ',' {
+			i = skipJSONSpace(data, i+1)
+		}
+	}
+
+	return members, nil
+}
+
+// jsonValueEnd returns the offset after the value starting at offset i, within the valid JSON document data.
+func jsonValueEnd(data []byte, i int) int {
+	depth := 0
+
+	for ; i < len(data); i++ {
+		switch data[i] {
+		case '"':
+			for i++; data[i] != '"'; i++ {
+				if data[i] == '\\' {
+					i++
+				}
+			}
+
+		case '{', '[':
+			depth++
+			continue
+
+		case '}', ']', ',', ' ', '\t', '\r', '\n':
+			if depth == 0 {
+				return i
+			}
+			if data[i] == '}' || data[i] == ']' {
+				depth--
+			}
+
+		default:
+			continue
+		}
+
+		if depth == 0 {
+			return i + 1
+		}
+	}
+
+	return i
+}
+
+// skipJSONSpace returns the offset of the first non-whitespace character at or after offset i.
+func skipJSONSpace(data []byte, i int) int {
+	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\r' || data[i] == '\n') {
+		i++
+	}
+	return i
+}
+
+// splice returns a copy of data, with the bytes between start and end replaced by value.
+func splice(data []byte, start int, end int, value []byte) []byte {
+	result := make([]byte, 0, len(data)-(end-start)+len(value))
+	result = append(result, data[:start]...)
+	result = append(result, value...)
+	return append(result, data[end:]...)
+}
@@ -0,0 +1,169 @@
+package dotnotation
+
+import (
+	"testing"
+)
+
+const bytesDocument = `{
+  "name": "example",
+  "servers": [
+    {"host": "a", "port": 80},
+    {"host": "b\"}", "port": 81}
+  ],
+  "empty": {},
+  "list": [],
+  "escaped.key": true,
+  "name": "duplicate"
+}`
+
+func TestGetBytes(t *testing.T) {
+	type TestCase struct {
+		Key   string
+		Value string
+		Err   string
+	}
+
+	testCases := []TestCase{
+		{Key: "name", Value: `"duplicate"`},
+		{Key: "servers.1", Value: `{"host": "b\"}", "port": 81}`},
+		{Key: "servers.1.port", Value: `81`},
+		{Key: "empty", Value: `{}`},
+		{Key: "escaped.key", Err: "cannot get non-existent property 'escaped' on a map"},
+		{Key: "missing", Err: "cannot get non-existent property 'missing' on a map"},
+		{Key: "servers.2", Err: "cannot get out of range property '2' on a slice"},
+		{Key: "servers.one", Err: "cannot get non-integer property 'one' on a slice"},
+		{Key: "name.one", Err: `cannot get property 'one' on JSON value "duplicate"`},
+	}
+
+	for i, testCase := range testCases {
+		value, err := GetBytes([]byte(bytesDocument), testCase.Key)
+
+		if (err == nil) != (testCase.Err == "") || (err != nil && err.Error() != testCase.Err) {
+			t.Errorf("test case %d expected error %q, got %v", i, testCase.Err, err)
+		}
+
+		if string(value) != testCase.Value {
+			t.Errorf("test case %d expected value %s, got %s", i, testCase.Value, value)
+		}
+	}
+
+	if value, err := GetBytes([]byte(` 7 `), "one"); err == nil || value != nil {
+		t.Fatalf("unexpected value %s / error %v", value, err)
+	}
+
+	if _, err := GetBytes([]byte(`{`), "one"); err == nil || err.Error() != "invalid JSON document" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestSetBytes(t *testing.T) {
+	type TestCase struct {
+		Key    string
+		Value  interface{}
+		Result string
+		Err    string
+	}
+
+	testCases := []TestCase{
+		{
+			Key:   "servers.0.port",
+			Value: 8080,
+			Result: `{
+  "name": "example",
+  "servers": [
+    {"host": "a", "port": 8080},
+    {"host": "b\"}", "port": 81}
+  ],
+  "empty": {},
+  "list": [],
+  "escaped.key": true,
+  "name": "duplicate"
+}`,
+		},
+		{
+			Key:   "servers.2",
+			Value: map[string]interface{}{"host": "c"},
+			Result: `{
+  "name": "example",
+  "servers": [
+    {"host": "a", "port": 80},
+    {"host": "b\"}", "port": 81},
+    {"host":"c"}
+  ],
+  "empty": {},
+  "list": [],
+  "escaped.key": true,
+  "name": "duplicate"
+}`,
+		},
+		{
+			Key:   "added",
+			Value: []int{1},
+			Result: `{
+  "name": "example",
+  "servers": [
+    {"host": "a", "port": 80},
+    {"host": "b\"}", "port": 81}
+  ],
+  "empty": {},
+  "list": [],
+  "escaped.key": true,
+  "name": "duplicate",
+  "added": [1]
+}`,
+		},
+		{
+			Key:   "empty.one",
+			Value: "two",
+			Result: `{
+  "name": "example",
+  "servers": [
+    {"host": "a", "port": 80},
+    {"host": "b\"}", "port": 81}
+  ],
+  "empty": {"one":"two"},
+  "list": [],
+  "escaped.key": true,
+  "name": "duplicate"
+}`,
+		},
+		{
+			Key:   "list.0",
+			Value: nil,
+			Result: `{
+  "name": "example",
+  "servers": [
+    {"host": "a", "port": 80},
+    {"host": "b\"}", "port": 81}
+  ],
+  "empty": {},
+  "list": [null],
+  "escaped.key": true,
+  "name": "duplicate"
+}`,
+		},
+		{Key: "list.1", Err: "cannot set out of range property '1' on a slice"},
+		{Key: "list.one", Err: "cannot set non-integer property 'one' on a slice"},
+		{Key: "name.one", Err: `cannot set property 'one' on JSON value "duplicate"`},
+		{Key: "missing.one", Err: "cannot get non-existent property 'missing' on a map"},
+		{Key: "name", Value: func() {}, Err: "json: unsupported type: func()"},
+	}
+
+	for i, testCase := range testCases {
+		data := []byte(bytesDocument)
+
+		result, err := SetBytes(data, testCase.Key, testCase.Value)
+
+		if (err == nil) != (testCase.Err == "") || (err != nil && err.Error() != testCase.Err) {
+			t.Errorf("test case %d expected error %q, got %v", i, testCase.Err, err)
+		}
+
+		if string(result) != testCase.Result {
+			t.Errorf("test case %d expected result:\n%s\ngot:\n%s", i, testCase.Result, result)
+		}
+
+		if string(data) != bytesDocument {
+			t.Errorf("test case %d modified the input", i)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package dotnotation
+
+import (
+	"context"
+	"errors"
+)
+
+// PropertyGetterContext may be implemented by custom types to support DefaultGetterContext, e.g. to lazily load
+// properties from a database.
+type PropertyGetterContext interface {
+	GetPropertyContext(ctx context.Context, property string) (interface{}, error)
+}
+
+// Resolver may be implemented by lazy values, such as references to remote documents, which GetContext will replace
+// with the result of Resolve, wherever they are found, including the target, and the result.
+type Resolver interface {
+	Resolve(ctx context.Context) (interface{}, error)
+}
+
+// DefaultGetterContext supports any target implementing PropertyGetterContext, otherwise it's equivalent to
+// DefaultGetter.
+func DefaultGetterContext(ctx context.Context, target interface{}, property string) (interface{}, error) {
+	if v, ok := target.(PropertyGetterContext); ok {
+		return v.GetPropertyContext(ctx, property)
+	}
+
+	return DefaultGetter(target, property)
+}
+
+// GetContext gets the value at key, within target, like Get, but uses GetterContext, if set, or Getter, if set,
+// otherwise DefaultGetterContext, and resolves any Resolver values. The context is checked before accessing each
+// property, and resolving each value.
+func (p Accessor) GetContext(ctx context.Context, target interface{}, key string) (interface{}, error) {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return nil, errors.New("no properties parsed from key: " + key)
+	}
+
+	target, err := p.resolve(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, property := range properties {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if target, err = p.getterContext(ctx, target, property); err != nil {
+			return nil, err
+		}
+
+		if target, err = p.resolve(ctx, target); err != nil {
+			return nil, err
+		}
+	}
+
+	return target, nil
+}
+
+// resolve calls Resolve until the value no longer implements Resolver.
+func (p Accessor) resolve(ctx context.Context, value interface{}) (interface{}, error) {
+	for {
+		resolver, ok := value.(Resolver)
+		if !ok {
+			return value, nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if value, err = resolver.Resolve(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p Accessor) getterContext(ctx context.Context, target interface{}, property string) (interface{}, error) {
+	if p.GetterContext != nil {
+		return p.GetterContext(ctx, target, property)
+	}
+
+	if p.Getter != nil {
+		return p.Getter(target, property)
+	}
+
+	return DefaultGetterContext(ctx, target, property)
+}
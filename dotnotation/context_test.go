@@ -0,0 +1,107 @@
+package dotnotation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type contextReference struct {
+	id    string
+	store map[string]interface{}
+	calls *int
+}
+
+func (r contextReference) Resolve(ctx context.Context) (interface{}, error) {
+	*r.calls++
+	value, ok := r.store[r.id]
+	if !ok {
+		return nil, errors.New("unknown reference " + r.id)
+	}
+	return value, nil
+}
+
+type contextLazy struct{}
+
+func (contextLazy) GetPropertyContext(ctx context.Context, property string) (interface{}, error) {
+	return property, ctx.Err()
+}
+
+func TestGetContext(t *testing.T) {
+	var calls int
+
+	store := map[string]interface{}{
+		"user": map[string]interface{}{"name": "joe"},
+	}
+
+	target := map[string]interface{}{
+		"user":    contextReference{id: "user", store: store, calls: &calls},
+		"missing": contextReference{id: "missing", store: store, calls: &calls},
+		"lazy":    contextLazy{},
+	}
+
+	if value, err := GetContext(context.Background(), target, "user.name"); err != nil || value != "joe" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if value, err := GetContext(context.Background(), target, "user"); err != nil || value.(map[string]interface{})["name"] != "joe" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if _, err := GetContext(context.Background(), target, "missing.name"); err == nil || err.Error() != "unknown reference missing" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if value, err := GetContext(context.Background(), target, "lazy.one"); err != nil || value != "one" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if calls != 3 {
+		t.Fatalf("unexpected calls %d", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := GetContext(ctx, target, "user.name"); err != context.Canceled {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if calls != 3 {
+		t.Fatalf("unexpected calls %d", calls)
+	}
+}
+
+func TestAccessor_GetContext_getters(t *testing.T) {
+	type key struct{}
+
+	accessor := Accessor{
+		GetterContext: func(ctx context.Context, target interface{}, property string) (interface{}, error) {
+			return ctx.Value(key{}), nil
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), key{}, "value")
+
+	if value, err := accessor.GetContext(ctx, nil, "one"); err != nil || value != "value" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	accessor = Accessor{
+		Getter: func(target interface{}, property string) (interface{}, error) {
+			return property, nil
+		},
+	}
+
+	if value, err := accessor.GetContext(ctx, nil, "one.two"); err != nil || value != "two" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	accessor.Parser = func(key string) []string {
+		return nil
+	}
+
+	if _, err := accessor.GetContext(ctx, nil, "key"); err == nil || err.Error() != "no properties parsed from key: key" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
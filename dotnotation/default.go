@@ -1,24 +1,99 @@
 package dotnotation
 
 import (
+	"encoding/json"
 	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-// DefaultGetter returns the property value of a given target, or an error, supporting types like encoding/json.
-// Supports one level of pointer indirection.
+// PropertyGetter may be implemented by custom types to support DefaultGetter.
+type PropertyGetter interface {
+	GetProperty(property string) (interface{}, error)
+}
+
+// PropertySetter may be implemented by custom types to support DefaultSetter.
+type PropertySetter interface {
+	SetProperty(property string, value interface{}) error
+}
+
+// PropertyDeleter may be implemented by custom types to support DefaultDeleter.
+type PropertyDeleter interface {
+	DeleteProperty(property string) error
+}
+
+// PropertyLister may be implemented by custom types to list their properties, in order, which is used to expand the
+// Wildcard property, see Document.Watch.
+type PropertyLister interface {
+	ListProperties() []string
+}
+
+// DefaultGetter returns the property value of a given target, or an error, supporting types like encoding/json, and
+// map[interface{}]interface{}, as decoded from YAML, see InterfaceMapKey. Any json.RawMessage is decoded on demand,
+// see Accessor.CacheRawJSON. Form values are also supported, as url.Values, http.Header, using canonical keys, or
+// map[string][]string, where each property is a []string, and *multipart.Form, where files are used if there is no
+// value.
+// Supports one level of pointer indirection, and any target implementing PropertyGetter.
 func DefaultGetter(target interface{}, property string) (interface{}, error) {
+	if v, ok := target.(PropertyGetter); ok {
+		return v.GetProperty(property)
+	}
+
 	// handle each type that is supported by simple unmarshalling of a json value
 	// https://golang.org/pkg/encoding/json/#Unmarshal
 	if v, ok := target.(*[]interface{}); ok {
 		target = *v
 	} else if v, ok := target.(*map[string]interface{}); ok {
 		target = *v
+	} else if v, ok := target.(*map[interface{}]interface{}); ok {
+		target = *v
+	} else if v, ok := target.(*json.RawMessage); ok && v != nil {
+		target = *v
+	} else if m, key, ok := stringsMap(target, property); ok {
+		target, property = m, key
 	}
 	switch v := target.(type) {
+	case json.RawMessage:
+		decoded, err := decodeRawJSON(v)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return DefaultGetter(decoded, property)
+
 	case []interface{}:
-		i, err := strconv.Atoi(property)
+		i, err := ParseIndex(property)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot get non-integer property '%s' on a slice", property)
+		}
+
+		if i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("cannot get out of range property '%s' on a slice", property)
+		}
+
+		return v[i], nil
+
+	case []string:
+		i, err := ParseIndex(property)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot get non-integer property '%s' on a slice", property)
+		}
+
+		if i < 0 || i >= len(v) {
+			return nil, fmt.Errorf("cannot get out of range property '%s' on a slice", property)
+		}
+
+		return v[i], nil
+
+	case []*multipart.FileHeader:
+		i, err := ParseIndex(property)
 
 		if err != nil {
 			return nil, fmt.Errorf("cannot get non-integer property '%s' on a slice", property)
@@ -39,20 +114,60 @@ func DefaultGetter(target interface{}, property string) (interface{}, error) {
 
 		return value, nil
 
+	case map[string][]string:
+		value, ok := v[property]
+
+		if !ok {
+			return nil, fmt.Errorf("cannot get non-existent property '%s' on a map", property)
+		}
+
+		return value, nil
+
+	case *multipart.Form:
+		if value, ok := v.Value[property]; ok {
+			return value, nil
+		}
+
+		if value, ok := v.File[property]; ok {
+			return value, nil
+		}
+
+		return nil, fmt.Errorf("cannot get non-existent property '%s' on a form", property)
+
+	case map[interface{}]interface{}:
+		key, ok := InterfaceMapKey(v, property)
+
+		if !ok {
+			return nil, fmt.Errorf("cannot get non-existent property '%s' on a map", property)
+		}
+
+		return v[key], nil
+
 	default:
 		return nil, fmt.Errorf("cannot get property '%s' on type %T", property, target)
 	}
 }
 
 // DefaultSetter sets the property value of a given target, to a given value, or returns an error, supporting types
-// like encoding/json.
-// Supports one level of pointer indirection, and appending to slices if a pointer is used.
+// like encoding/json, and map[interface{}]interface{}, where any existing key is replaced, see InterfaceMapKey,
+// otherwise a string key is added. Properties of the form values supported by DefaultGetter may be set to a string,
+// replacing any existing values, or a []string.
+// Supports one level of pointer indirection, appending to slices if a pointer is used, and any target implementing
+// PropertySetter.
 func DefaultSetter(target interface{}, property string, value interface{}) error {
+	if v, ok := target.(PropertySetter); ok {
+		return v.SetProperty(property, value)
+	}
+
+	if m, key, ok := stringsMap(target, property); ok {
+		target, property = m, key
+	}
+
 	// handle each type that is supported by simple unmarshalling of a json value
 	// https://golang.org/pkg/encoding/json/#Unmarshal
 	switch v := target.(type) {
 	case []interface{}:
-		i, err := strconv.Atoi(property)
+		i, err := ParseIndex(property)
 
 		if err != nil {
 			return fmt.Errorf("cannot set non-integer property '%s' on a slice", property)
@@ -65,12 +180,57 @@ func DefaultSetter(target interface{}, property string, value interface{}) error
 		v[i] = value
 		return nil
 
+	case []string:
+		s, ok := value.(string)
+
+		if !ok {
+			return fmt.Errorf("cannot set property '%s' to type %T on a slice of strings", property, value)
+		}
+
+		i, err := ParseIndex(property)
+
+		if err != nil {
+			return fmt.Errorf("cannot set non-integer property '%s' on a slice", property)
+		}
+
+		if i < 0 || i >= len(v) {
+			return fmt.Errorf("cannot set out of range property '%s' on a slice", property)
+		}
+
+		v[i] = s
+		return nil
+
 	case map[string]interface{}:
 		v[property] = value
 		return nil
 
+	case map[string][]string:
+		switch value := value.(type) {
+		case []string:
+			v[property] = value
+		case string:
+			v[property] = []string{value}
+		default:
+			return fmt.Errorf("cannot set property '%s' to type %T on a map of strings", property, value)
+		}
+		return nil
+
+	case *multipart.Form:
+		if v.Value == nil {
+			v.Value = make(map[string][]string)
+		}
+		return DefaultSetter(v.Value, property, value)
+
+	case map[interface{}]interface{}:
+		if key, ok := InterfaceMapKey(v, property); ok {
+			v[key] = value
+		} else {
+			v[property] = value
+		}
+		return nil
+
 	case *[]interface{}:
-		i, err := strconv.Atoi(property)
+		i, err := ParseIndex(property)
 
 		if err != nil {
 			return fmt.Errorf("cannot set non-integer property '%s' on a slice", property)
@@ -88,15 +248,255 @@ func DefaultSetter(target interface{}, property string, value interface{}) error
 		(*v)[i] = value
 		return nil
 
+	case *[]string:
+		s, ok := value.(string)
+
+		if !ok {
+			return fmt.Errorf("cannot set property '%s' to type %T on a slice of strings", property, value)
+		}
+
+		if i, err := ParseIndex(property); err == nil && i == len(*v) {
+			*v = append(*v, s)
+			return nil
+		}
+
+		return DefaultSetter(*v, property, value)
+
 	case *map[string]interface{}:
 		(*v)[property] = value
 		return nil
 
+	case *map[interface{}]interface{}:
+		return DefaultSetter(*v, property, value)
+
 	default:
 		return fmt.Errorf("cannot set property '%s' on type %T", property, target)
 	}
 }
 
+// DefaultDeleter removes the property from a given target, or returns an error, supporting types like encoding/json,
+// and the form values supported by DefaultGetter.
+// Supports one level of pointer indirection, which is required to remove elements from slices, and any target
+// implementing PropertyDeleter.
+func DefaultDeleter(target interface{}, property string) error {
+	if v, ok := target.(PropertyDeleter); ok {
+		return v.DeleteProperty(property)
+	}
+
+	if m, key, ok := stringsMap(target, property); ok {
+		target, property = m, key
+	}
+
+	switch v := target.(type) {
+	case map[string]interface{}:
+		if _, ok := v[property]; !ok {
+			return fmt.Errorf("cannot delete non-existent property '%s' on a map", property)
+		}
+
+		delete(v, property)
+		return nil
+
+	case map[string][]string:
+		if _, ok := v[property]; !ok {
+			return fmt.Errorf("cannot delete non-existent property '%s' on a map", property)
+		}
+
+		delete(v, property)
+		return nil
+
+	case *multipart.Form:
+		_, value := v.Value[property]
+		_, file := v.File[property]
+
+		if !value && !file {
+			return fmt.Errorf("cannot delete non-existent property '%s' on a form", property)
+		}
+
+		delete(v.Value, property)
+		delete(v.File, property)
+		return nil
+
+	case map[interface{}]interface{}:
+		key, ok := InterfaceMapKey(v, property)
+
+		if !ok {
+			return fmt.Errorf("cannot delete non-existent property '%s' on a map", property)
+		}
+
+		delete(v, key)
+		return nil
+
+	case *[]interface{}:
+		i, err := ParseIndex(property)
+
+		if err != nil {
+			return fmt.Errorf("cannot delete non-integer property '%s' on a slice", property)
+		}
+
+		if i < 0 || i >= len(*v) {
+			return fmt.Errorf("cannot delete out of range property '%s' on a slice", property)
+		}
+
+		// copy, rather than shifting in place, as the backing array may be shared
+		result := make([]interface{}, 0, len(*v)-1)
+		result = append(result, (*v)[:i]...)
+		*v = append(result, (*v)[i+1:]...)
+		return nil
+
+	case *[]string:
+		i, err := ParseIndex(property)
+
+		if err != nil {
+			return fmt.Errorf("cannot delete non-integer property '%s' on a slice", property)
+		}
+
+		if i < 0 || i >= len(*v) {
+			return fmt.Errorf("cannot delete out of range property '%s' on a slice", property)
+		}
+
+		result := make([]string, 0, len(*v)-1)
+		result = append(result, (*v)[:i]...)
+		*v = append(result, (*v)[i+1:]...)
+		return nil
+
+	case *map[string]interface{}:
+		return DefaultDeleter(*v, property)
+
+	case *map[interface{}]interface{}:
+		return DefaultDeleter(*v, property)
+
+	default:
+		return fmt.Errorf("cannot delete property '%s' on type %T", property, target)
+	}
+}
+
+// InterfaceMapKey returns the key of m matching property, which may be a string, or an integer, bool, or float, as
+// decoded from YAML, matched by it's string form, e.g. the property "1" matches the key int(1).
+func InterfaceMapKey(m map[interface{}]interface{}, property string) (interface{}, bool) {
+	candidates := []interface{}{property}
+
+	if i, err := strconv.ParseInt(property, 10, 64); err == nil && property == strconv.FormatInt(i, 10) {
+		candidates = append(candidates, int(i), i)
+	} else if u, err := strconv.ParseUint(property, 10, 64); err == nil && property == strconv.FormatUint(u, 10) {
+		candidates = append(candidates, u)
+	}
+
+	if b, err := strconv.ParseBool(property); err == nil && property == strconv.FormatBool(b) {
+		candidates = append(candidates, b)
+	}
+
+	if f, err := strconv.ParseFloat(property, 64); err == nil && property == strconv.FormatFloat(f, 'g', -1, 64) {
+		candidates = append(candidates, f)
+	}
+
+	for _, key := range candidates {
+		if _, ok := m[key]; ok {
+			return key, true
+		}
+	}
+
+	return nil, false
+}
+
+// ParseIndex parses property as a slice index, which must be a decimal integer, formatted like strconv.Itoa, e.g.
+// "+1", "01", and " 1" are all rejected, unlike strconv.Atoi. Negative indexes are parsed, but are always out of range.
+func ParseIndex(property string) (int, error) {
+	i, err := strconv.Atoi(property)
+
+	if err != nil || strconv.Itoa(i) != property {
+		return 0, fmt.Errorf("invalid index '%s'", property)
+	}
+
+	return i, nil
+}
+
+// stringsMap returns target as a map[string][]string, if it's a url.Values, http.Header, or map[string][]string, or a
+// pointer to one, along with property, which is canonicalised for http.Header, see http.CanonicalHeaderKey.
+func stringsMap(target interface{}, property string) (map[string][]string, string, bool) {
+	switch v := target.(type) {
+	case map[string][]string:
+		return v, property, true
+	case url.Values:
+		return v, property, true
+	case http.Header:
+		return v, http.CanonicalHeaderKey(property), true
+	case *map[string][]string:
+		return *v, property, true
+	case *url.Values:
+		return *v, property, true
+	case *http.Header:
+		return *v, http.CanonicalHeaderKey(property), true
+	default:
+		return nil, "", false
+	}
+}
+
+// listProperties returns the properties of the types supported by DefaultGetter, which are the indexes of a slice,
+// or the sorted keys of a map, or the result of PropertyLister, or false if target is not supported.
+func listProperties(target interface{}) ([]string, bool) {
+	if v, ok := target.(PropertyLister); ok {
+		return v.ListProperties(), true
+	}
+
+	if v, ok := target.(json.RawMessage); ok {
+		if decoded, err := decodeRawJSON(v); err == nil {
+			target = decoded
+		}
+	}
+
+	if v, ok := target.(*[]interface{}); ok {
+		target = *v
+	} else if v, ok := target.(*map[string]interface{}); ok {
+		target = *v
+	} else if v, ok := target.(*map[interface{}]interface{}); ok {
+		target = *v
+	} else if m, _, ok := stringsMap(target, ""); ok {
+		target = m
+	}
+	switch v := target.(type) {
+	case []interface{}:
+		properties := make([]string, len(v))
+		for i := range v {
+			properties[i] = strconv.Itoa(i)
+		}
+		return properties, true
+
+	case []string:
+		properties := make([]string, len(v))
+		for i := range v {
+			properties[i] = strconv.Itoa(i)
+		}
+		return properties, true
+
+	case map[string][]string:
+		properties := make([]string, 0, len(v))
+		for property := range v {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		return properties, true
+
+	case map[string]interface{}:
+		properties := make([]string, 0, len(v))
+		for property := range v {
+			properties = append(properties, property)
+		}
+		sort.Strings(properties)
+		return properties, true
+
+	case map[interface{}]interface{}:
+		properties := make([]string, 0, len(v))
+		for key := range v {
+			properties = append(properties, fmt.Sprint(key))
+		}
+		sort.Strings(properties)
+		return properties, true
+
+	default:
+		return nil, false
+	}
+}
+
 // DefaultParser simply converts a string key into a list of properties that must be accessed in order, to achieve
 // the dot notation get or set.
 func DefaultParser(key string) []string {
@@ -4,6 +4,9 @@ import (
 	"testing"
 	"github.com/go-test/deep"
 	"strings"
+	"mime/multipart"
+	"net/http"
+	"net/url"
 )
 
 type dummyStruct struct {
@@ -487,3 +490,348 @@ func TestDefaultParser(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultDeleter(t *testing.T) {
+	slice := []interface{}{1, 2, 3}
+	if err := DefaultDeleter(&slice, "1"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if diff := deep.Equal([]interface{}{1, 3}, slice); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	for _, property := range []string{"-1", "2", "PROPERTY"} {
+		if err := DefaultDeleter(&slice, property); err == nil {
+			t.Errorf("expected error for property %s", property)
+		}
+	}
+
+	// slices must be pointers, as they cannot otherwise be resized
+	if err := DefaultDeleter(slice, "0"); err == nil {
+		t.Error("expected error for a slice value")
+	}
+
+	m := map[string]interface{}{"one": 1, "two": 2}
+	if err := DefaultDeleter(m, "one"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := DefaultDeleter(&m, "two"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := DefaultDeleter(m, "three"); err == nil {
+		t.Error("expected error for a missing property")
+	}
+	if diff := deep.Equal(map[string]interface{}{}, m); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	for _, value := range []interface{}{1, "string", nil, dummyStruct{"value"}} {
+		if err := DefaultDeleter(value, "1"); err == nil {
+			t.Errorf("expected error for %T %v", value, value)
+		}
+	}
+}
+
+type propertyContainer struct {
+	values map[string]interface{}
+}
+
+func (c *propertyContainer) GetProperty(property string) (interface{}, error) {
+	if property == "self" {
+		return c, nil
+	}
+	return c.values[property], nil
+}
+
+func (c *propertyContainer) SetProperty(property string, value interface{}) error {
+	c.values[property] = value
+	return nil
+}
+
+func (c *propertyContainer) DeleteProperty(property string) error {
+	delete(c.values, property)
+	return nil
+}
+
+func (c *propertyContainer) ListProperties() []string {
+	properties, _ := listProperties(c.values)
+	return properties
+}
+
+func TestDefault_propertyInterfaces(t *testing.T) {
+	container := &propertyContainer{values: map[string]interface{}{"one": 1}}
+	target := map[string]interface{}{"container": container}
+
+	if value, err := Get(target, "container.self.one"); err != nil || value != 1 {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if err := Set(target, "container.two", 2); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := (Accessor{}).Delete(target, "container.one"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"two": 2}, container.values); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if properties, ok := listProperties(container); !ok || len(properties) != 1 || properties[0] != "two" {
+		t.Fatalf("unexpected properties %v", properties)
+	}
+}
+
+func TestDefault_interfaceMap(t *testing.T) {
+	// as decoded by gopkg.in/yaml.v2
+	target := map[interface{}]interface{}{
+		"db": map[interface{}]interface{}{
+			"hosts": []interface{}{"one"},
+			1:       "int",
+			true:    "bool",
+			1.5:     "float",
+		},
+	}
+
+	testCases := []struct {
+		key     string
+		success bool
+		result  interface{}
+	}{
+		{key: "db.hosts.0", success: true, result: "one"},
+		{key: "db.1", success: true, result: "int"},
+		{key: "db.true", success: true, result: "bool"},
+		{key: "db.1.5", success: false},
+		{key: "db.2", success: false},
+		{key: "db.01", success: false},
+		{key: "db.+1", success: false},
+		{key: "db.false", success: false},
+	}
+
+	for _, testCase := range testCases {
+		result, err := Get(target, testCase.key)
+
+		if (err == nil) != testCase.success || result != testCase.result {
+			t.Errorf("%s failed: unexpected result %v / error %v", testCase.key, result, err)
+		}
+	}
+
+	if result, err := Get(&target, "db.true"); err != nil || result != "bool" {
+		t.Errorf("unexpected result %v / error %v", result, err)
+	}
+
+	if err := Set(target, "db.1", "replaced"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := Set(&target, "db.hosts.1", "two"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := Set(target, "db.port", 5432); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := (Accessor{}).Delete(target, "db.true"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := (Accessor{}).Delete(&target, "db.missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := map[interface{}]interface{}{
+		"db": map[interface{}]interface{}{
+			"hosts": []interface{}{"one", "two"},
+			1:       "replaced",
+			1.5:     "float",
+			"port":  5432,
+		},
+	}
+
+	if diff := deep.Equal(expected, target); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if properties, ok := listProperties(target["db"]); !ok || strings.Join(properties, ",") != "1,1.5,hosts,port" {
+		t.Fatalf("unexpected properties %v", properties)
+	}
+}
+
+func TestParseIndex(t *testing.T) {
+	testCases := []struct {
+		property string
+		success  bool
+		result   int
+	}{
+		{property: "0", success: true, result: 0},
+		{property: "12", success: true, result: 12},
+		{property: "-1", success: true, result: -1},
+		{property: "+1", success: false},
+		{property: "01", success: false},
+		{property: "-0", success: false},
+		{property: " 1", success: false},
+		{property: "1 ", success: false},
+		{property: "1.0", success: false},
+		{property: "", success: false},
+		{property: "99999999999999999999", success: false},
+	}
+
+	for _, testCase := range testCases {
+		result, err := ParseIndex(testCase.property)
+
+		if (err == nil) != testCase.success || result != testCase.result {
+			t.Errorf("%q failed: unexpected result %v / error %v", testCase.property, result, err)
+		}
+	}
+
+	slice := []interface{}{"zero", "one"}
+
+	for _, property := range []string{"+1", "01", " 1"} {
+		if _, err := DefaultGetter(slice, property); err == nil {
+			t.Errorf("expected error for property %q", property)
+		}
+
+		if err := DefaultSetter(slice, property, "two"); err == nil {
+			t.Errorf("expected error for property %q", property)
+		}
+	}
+
+	if diff := deep.Equal([]interface{}{"zero", "one"}, slice); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+}
+
+func TestDefault_formValues(t *testing.T) {
+	query := url.Values{"name": {"joe", "bob"}}
+	header := http.Header{}
+	header.Set("Content-Type", "text/plain")
+	form := &multipart.Form{
+		Value: map[string][]string{"name": {"joe"}},
+		File:  map[string][]*multipart.FileHeader{"upload": {{Filename: "one.txt"}}},
+	}
+
+	target := map[string]interface{}{
+		"query":  query,
+		"header": header,
+		"form":   form,
+		"raw":    map[string][]string{"a": {"b"}},
+	}
+
+	testCases := []struct {
+		key     string
+		success bool
+		result  interface{}
+	}{
+		{key: "query.name", success: true, result: []string{"joe", "bob"}},
+		{key: "query.name.1", success: true, result: "bob"},
+		{key: "query.name.2", success: false},
+		{key: "query.name.+1", success: false},
+		{key: "query.missing", success: false},
+		{key: "header.content-type.0", success: true, result: "text/plain"},
+		{key: "raw.a.0", success: true, result: "b"},
+		{key: "form.name.0", success: true, result: "joe"},
+		{key: "form.upload.0", success: true, result: form.File["upload"][0]},
+		{key: "form.upload.1", success: false},
+		{key: "form.missing", success: false},
+	}
+
+	for _, testCase := range testCases {
+		result, err := Get(target, testCase.key)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.key, err)
+		}
+
+		if diff := deep.Equal(testCase.result, result); diff != nil {
+			t.Errorf("%s failed: %s", testCase.key, strings.Join(diff, ", "))
+		}
+	}
+
+	if err := Set(target, "query.name.0", "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(target, "header.x-request-id", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(target, "raw.c", []string{"d", "e"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(target, "form.age", "30"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(target, "query.name.0", 1); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if err := Set(target, "query.name", 1); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if err := DefaultSetter(&query, "name", "carol"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (Accessor{}).Delete(target, "header.content-type"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (Accessor{}).Delete(target, "form.upload"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := (Accessor{}).Delete(target, "form.upload"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if err := (Accessor{}).Delete(target, "raw.missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	names := []string{"a", "b", "c"}
+
+	if err := DefaultSetter(&names, "3", "d"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DefaultDeleter(&names, "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := DefaultDeleter(&names, "3"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if diff := deep.Equal([]string{"b", "c", "d"}, names); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(url.Values{"name": {"carol"}}, query); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(http.Header{"X-Request-Id": {"1"}}, header); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(map[string][]string{"name": {"joe"}, "age": {"30"}}, form.Value); diff != nil || len(form.File) != 0 {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(map[string][]string{"a": {"b"}, "c": {"d", "e"}}, target["raw"]); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	if properties, ok := listProperties(header); !ok || strings.Join(properties, ",") != "X-Request-Id" {
+		t.Fatalf("unexpected properties %v", properties)
+	}
+
+	if properties, ok := listProperties(names); !ok || strings.Join(properties, ",") != "0,1,2" {
+		t.Fatalf("unexpected properties %v", properties)
+	}
+}
@@ -0,0 +1,171 @@
+package dotnotation
+
+import (
+	"errors"
+	"sync"
+)
+
+// Document wraps a root value, such as a map[string]interface{}, synchronising access to it, so that it may be
+// safely read and written by multiple goroutines. To allow appending to a root slice, use a *[]interface{}.
+type Document struct {
+	mutex    sync.RWMutex
+	accessor Accessor
+	root     interface{}
+	watchers []*watcher
+	// pending lists changes that have not yet been delivered to watchers, in order, see deliver
+	pending []notification
+	// delivering is true while a goroutine is delivering pending changes
+	delivering bool
+}
+
+type notification struct {
+	watcher *watcher
+	change  Change
+}
+
+// NewDocument returns a Document wrapping root, which should not be accessed directly after this call, using
+// accessor for all operations, except that CacheRawJSON is disabled, as Get only holds a read lock.
+func NewDocument(accessor Accessor, root interface{}) *Document {
+	accessor.CacheRawJSON = false
+	return &Document{
+		accessor: accessor,
+		root:     root,
+	}
+}
+
+// Get returns a deep copy, as per Accessor.Copy, of the value at key, so the result may be safely used, and modified,
+// without synchronisation.
+func (d *Document) Get(key string) (interface{}, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+
+	value, err := d.accessor.Get(d.root, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return deepCopy(value), nil
+}
+
+// Set sets the value at key, as per Accessor.Set, the value should not be modified after this call.
+func (d *Document) Set(key string, value interface{}) error {
+	properties := d.accessor.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return d.update(properties, func(root interface{}) error {
+		return d.accessor.set(root, properties, value)
+	})
+}
+
+// Delete removes the value at key, as per Accessor.Delete.
+func (d *Document) Delete(key string) error {
+	properties := d.accessor.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return d.update(properties, func(root interface{}) error {
+		return d.accessor.delete(root, properties)
+	})
+}
+
+// Merge merges value into the value at key, as per Accessor.Merge, the value should not be modified after this call.
+func (d *Document) Merge(key string, value interface{}) error {
+	properties := d.accessor.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return d.update(properties, func(root interface{}) error {
+		return d.accessor.merge(root, properties, value)
+	})
+}
+
+// Update calls fn with the root value, while holding an exclusive lock, allowing multiple operations to be performed
+// without interleaving with any others. The root value must not be retained after fn returns. As any value may be
+// changed, every watcher will be checked for changes.
+func (d *Document) Update(fn func(root interface{}) error) error {
+	return d.update(nil, fn)
+}
+
+// update calls fn while holding an exclusive lock, then notifies watchers of any changes to their matching paths,
+// only checking watchers with patterns that overlap properties, or all watchers, if properties is nil. The values
+// before the change are deep copies, as fn may modify them, but only the values that changed are copied after.
+func (d *Document) update(properties []string, fn func(root interface{}) error) error {
+	d.mutex.Lock()
+
+	watchers := make([]*watcher, 0, len(d.watchers))
+	before := make([]map[string]interface{}, 0, len(d.watchers))
+
+	for _, w := range d.watchers {
+		if properties != nil && !w.overlaps(properties) {
+			continue
+		}
+
+		watchers = append(watchers, w)
+		before = append(before, d.accessor.expand(d.root, w.pattern, true))
+	}
+
+	err := fn(d.root)
+
+	for i, w := range watchers {
+		for _, change := range diffValues(before[i], d.accessor.expand(d.root, w.pattern, false)) {
+			change.New = deepCopy(change.New)
+			d.pending = append(d.pending, notification{watcher: w, change: change})
+		}
+	}
+
+	if d.delivering || len(d.pending) == 0 {
+		d.mutex.Unlock()
+		return err
+	}
+
+	d.delivering = true
+
+	d.mutex.Unlock()
+
+	d.deliver()
+
+	return err
+}
+
+// deliver calls watchers for pending changes, in the order they were made, until there are none, which ensures that
+// changes are never delivered out of order, or concurrently, even if they were made by different goroutines, or by
+// the watchers themselves.
+func (d *Document) deliver() {
+	done := false
+
+	defer func() {
+		// a watcher panicked
+		if !done {
+			d.mutex.Lock()
+			d.delivering = false
+			d.mutex.Unlock()
+		}
+	}()
+
+	for {
+		d.mutex.Lock()
+
+		pending := d.pending
+		d.pending = nil
+
+		if len(pending) == 0 {
+			d.delivering = false
+			d.mutex.Unlock()
+			done = true
+			return
+		}
+
+		d.mutex.Unlock()
+
+		for _, n := range pending {
+			n.watcher.fn(n.change)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package dotnotation
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDocument(t *testing.T) {
+	document := NewDocument(Accessor{}, map[string]interface{}{
+		"db": map[string]interface{}{
+			"hosts": []interface{}{"one"},
+		},
+	})
+
+	if err := document.Set("db.hosts.1", "two"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := document.Set("db.port", 5432); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := document.Delete("db.hosts.0"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := document.Delete("db.missing"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	value, err := document.Get("db")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"hosts": []interface{}{"two"},
+		"port":  5432,
+	}
+
+	if diff := deep.Equal(expected, value); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	// the result of get is a copy
+	value.(map[string]interface{})["port"] = 1
+
+	if value, err := document.Get("db.port"); err != nil || value != 5432 {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	err = document.Update(func(root interface{}) error {
+		return errors.New("some error")
+	})
+
+	if err == nil || err.Error() != "some error" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestDocument_concurrent(t *testing.T) {
+	document := NewDocument(Accessor{}, map[string]interface{}{
+		"counter": 0,
+		"items":   []interface{}{},
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				err := document.Update(func(root interface{}) error {
+					value, err := Accessor{}.Get(root, "counter")
+					if err != nil {
+						return err
+					}
+
+					return Accessor{}.Set(root, "counter", value.(int)+1)
+				})
+
+				if err != nil {
+					t.Error(err)
+				}
+
+				if err := document.Set("worker"+strconv.Itoa(i), j); err != nil {
+					t.Error(err)
+				}
+
+				if _, err := document.Get("items"); err != nil {
+					t.Error(err)
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	if value, err := document.Get("counter"); err != nil || value != 1000 {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+}
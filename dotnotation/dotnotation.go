@@ -1,17 +1,88 @@
 // Package dotnotation provides dot notation getters and setters for manipulating data structures.
 package dotnotation
 
-// DefaultAccessor, used for the exported Set and Get functions.
-var DefaultAccessor Accessor
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync/atomic"
+)
+
+// defaultAccessor stores the Accessor used for the exported package functions, see Default.
+var defaultAccessor atomic.Value
+
+// Default returns the Accessor used for the exported package functions, such as Get and Set, which is the zero
+// value, unless SetDefault has been called. It's safe to call concurrently with SetDefault.
+func Default() Accessor {
+	if accessor, ok := defaultAccessor.Load().(Accessor); ok {
+		return accessor
+	}
+
+	return Accessor{}
+}
+
+// SetDefault atomically replaces the Accessor used for the exported package functions.
+func SetDefault(accessor Accessor) {
+	defaultAccessor.Store(accessor)
+}
+
+// OverrideDefault replaces the Accessor used for the exported package functions, returning a function that restores
+// the previous Accessor, intended for use in tests, e.g. `defer OverrideDefault(accessor)()`.
+func OverrideDefault(accessor Accessor) (restore func()) {
+	previous := Default()
+	SetDefault(accessor)
+	return func() {
+		SetDefault(previous)
+	}
+}
 
 // Set sets a value using dot notation, by default it supports generic []interface{} and map[string]interface{} types.
-// It's behaviour can be configured by modifying the DefaultAccessor variable.
+// It's behaviour can be configured using SetDefault.
 func Set(target interface{}, key string, value interface{}) error {
-	return DefaultAccessor.Set(target, key, value)
+	return Default().Set(target, key, value)
 }
 
 // Get sets a value using dot notation, by default it supports generic []interface{} and map[string]interface{} types.
-// It's behaviour can be configured by modifying the DefaultAccessor variable.
+// It's behaviour can be configured using SetDefault.
 func Get(target interface{}, key string) (interface{}, error) {
-	return DefaultAccessor.Get(target, key)
+	return Default().Get(target, key)
+}
+
+// GetContext gets a value using dot notation, like Get, with support for lazily resolved values, see
+// Accessor.GetContext.
+// It's behaviour can be configured using SetDefault.
+func GetContext(ctx context.Context, target interface{}, key string) (interface{}, error) {
+	return Default().GetContext(ctx, target, key)
+}
+
+// GetFromReader reads a single JSON value from r, returning the values at each of keys, by key, only decoding the
+// requested values, see Accessor.GetFromReader.
+// It's behaviour can be configured using SetDefault.
+func GetFromReader(r io.Reader, keys ...string) (map[string]interface{}, error) {
+	return Default().GetFromReader(r, keys...)
+}
+
+// GetBytes returns the raw JSON of the value at key, within the JSON document data, see Accessor.GetBytes.
+// It's behaviour can be configured using SetDefault.
+func GetBytes(data []byte, key string) (json.RawMessage, error) {
+	return Default().GetBytes(data, key)
+}
+
+// SetBytes returns a copy of the JSON document data, with the value at key set to value, preserving the formatting of
+// the rest of the document, see Accessor.SetBytes.
+// It's behaviour can be configured using SetDefault.
+func SetBytes(data []byte, key string, value interface{}) ([]byte, error) {
+	return Default().SetBytes(data, key, value)
+}
+
+// Bind populates the tagged fields of the struct pointed to by dst, using values from src, see Accessor.Bind.
+// It's behaviour can be configured using SetDefault.
+func Bind(src interface{}, dst interface{}) error {
+	return Default().Bind(src, dst)
+}
+
+// Unbind sets the value of each tagged field of src at it's key within dst, see Accessor.Unbind.
+// It's behaviour can be configured using SetDefault.
+func Unbind(src interface{}, dst *map[string]interface{}) error {
+	return Default().Unbind(src, dst)
 }
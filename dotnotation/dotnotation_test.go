@@ -29,3 +29,46 @@ func TestSet(t *testing.T) {
 		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
 	}
 }
+
+func TestOverrideDefault(t *testing.T) {
+	if diff := deep.Equal(Accessor{}, Default()); diff != nil {
+		t.Fatalf("unexpected diff: %v", strings.Join(diff, ", "))
+	}
+
+	restore := OverrideDefault(Accessor{
+		Getter: func(target interface{}, property string) (interface{}, error) {
+			return property, nil
+		},
+	})
+
+	value, err := Get(nil, "one.two")
+
+	if nil != err || value != "two" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	restore()
+
+	if _, err := Get(nil, "one.two"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestSetDefault_concurrent(t *testing.T) {
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			SetDefault(Accessor{MaxPadding: i})
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		_, _ = Get([]interface{}{0}, "0")
+	}
+
+	<-done
+
+	SetDefault(Accessor{})
+}
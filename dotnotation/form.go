@@ -0,0 +1,118 @@
+package dotnotation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FormParser parses bracketed form keys, as used by PHP and Rails, e.g. "user[address][city]" is parsed as the
+// properties "user", "address", and "city", and "tags[]" is parsed with an empty last property. Keys that are not in
+// this form are parsed as a single property.
+func FormParser(key string) []string {
+	i := strings.IndexByte(key, '[')
+	if i <= 0 {
+		return []string{key}
+	}
+
+	properties := []string{key[:i]}
+
+	for rest := key[i:]; rest != ""; {
+		end := strings.IndexByte(rest, ']')
+		if rest[0] != '[' || end < 0 {
+			return []string{key}
+		}
+
+		properties = append(properties, rest[1:end])
+		rest = rest[end+1:]
+	}
+
+	return properties
+}
+
+// DecodeForm decodes form values, e.g. url.Values, into a nested document, parsing each key using FormParser, where
+// each value is the last value for it's key, as per PHP and Rails, unless the key ends with "[]", in which case it's
+// all of the values, as a []interface{}. Maps with keys that are the indexes of a slice are converted to slices, e.g.
+// "items[0][name]" decodes to a []interface{} of map[string]interface{}. An error is returned if keys conflict, e.g.
+// "a" and "a[b]", or if any other property is empty.
+func DecodeForm(values map[string][]string) (map[string]interface{}, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	// any key that is a prefix of another is sorted first, so conflicts are always detected
+	sort.Strings(keys)
+
+	var accessor Accessor
+
+	root := make(map[string]interface{})
+
+	for _, key := range keys {
+		v := values[key]
+
+		if len(v) == 0 {
+			continue
+		}
+
+		properties := FormParser(key)
+
+		var value interface{} = v[len(v)-1]
+
+		if len(properties) > 1 && properties[len(properties)-1] == "" {
+			properties = properties[:len(properties)-1]
+
+			all := make([]interface{}, len(v))
+			for i, s := range v {
+				all[i] = s
+			}
+
+			value = all
+		}
+
+		for _, property := range properties {
+			if property == "" {
+				return nil, fmt.Errorf("unsupported form key '%s'", key)
+			}
+		}
+
+		if _, err := accessor.get(root, properties); err == nil {
+			return nil, fmt.Errorf("conflicting form key '%s'", key)
+		}
+
+		if err := accessor.create(root, properties, value); err != nil {
+			return nil, fmt.Errorf("conflicting form key '%s': %v", key, err)
+		}
+	}
+
+	for key, value := range root {
+		root[key] = formSlices(value)
+	}
+
+	return root, nil
+}
+
+// formSlices recursively converts any map[string]interface{} with keys that are the indexes of a slice, to a slice.
+func formSlices(value interface{}) interface{} {
+	m, ok := value.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return value
+	}
+
+	for key, element := range m {
+		m[key] = formSlices(element)
+	}
+
+	slice := make([]interface{}, len(m))
+
+	for key, element := range m {
+		i, err := ParseIndex(key)
+		if err != nil || i < 0 || i >= len(slice) {
+			return m
+		}
+
+		slice[i] = element
+	}
+
+	return slice
+}
@@ -0,0 +1,92 @@
+package dotnotation
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestFormParser(t *testing.T) {
+	testCases := []struct {
+		key        string
+		properties []string
+	}{
+		{key: "name", properties: []string{"name"}},
+		{key: "user[address][city]", properties: []string{"user", "address", "city"}},
+		{key: "tags[]", properties: []string{"tags", ""}},
+		{key: "items[0][name]", properties: []string{"items", "0", "name"}},
+		{key: "a.b[c]", properties: []string{"a.b", "c"}},
+		{key: "[a]", properties: []string{"[a]"}},
+		{key: "a[b", properties: []string{"a[b"}},
+		{key: "a[b]c", properties: []string{"a[b]c"}},
+		{key: "a[b[c]]", properties: []string{"a[b[c]]"}},
+		{key: "", properties: []string{""}},
+	}
+
+	for _, testCase := range testCases {
+		if diff := deep.Equal(testCase.properties, FormParser(testCase.key)); diff != nil {
+			t.Errorf("%q failed: %s", testCase.key, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestDecodeForm(t *testing.T) {
+	values, err := url.ParseQuery(
+		"user[name]=joe&user[address][city]=Brisbane&user[address][city]=Sydney&tags[]=a&tags[]=b" +
+			"&items[1][name]=second&items[0][name]=first&sparse[1]=one&empty=",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values["none"] = nil
+
+	result, err := DecodeForm(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":    "joe",
+			"address": map[string]interface{}{"city": "Sydney"},
+		},
+		"tags": []interface{}{"a", "b"},
+		"items": []interface{}{
+			map[string]interface{}{"name": "first"},
+			map[string]interface{}{"name": "second"},
+		},
+		"sparse": map[string]interface{}{"1": "one"},
+		"empty":  "",
+	}
+
+	if diff := deep.Equal(expected, result); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+
+	if value, err := Get(result, "items.1.name"); err != nil || value != "second" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	errors := []struct {
+		query string
+		err   string
+	}{
+		{"a=1&a[b]=2", "conflicting form key 'a[b]': cannot set property 'b' on type string"},
+		{"a=1&a[]=2", "conflicting form key 'a[]'"},
+		{"a[][b]=1", "unsupported form key 'a[][b]'"},
+	}
+
+	for _, e := range errors {
+		values, err := url.ParseQuery(e.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := DecodeForm(values); err == nil || err.Error() != e.err {
+			t.Errorf("%s: unexpected error %v", e.query, err)
+		}
+	}
+}
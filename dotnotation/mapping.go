@@ -0,0 +1,90 @@
+package dotnotation
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Rule describes how to project a single value from a source document, for a Mapping.
+type Rule struct {
+	// From is the key of the value within the source document.
+	From string
+	// To is the key of the value within the result, missing intermediate values are created as
+	// map[string]interface{}.
+	To string
+	// Transform optionally converts the value found at From, before it is set, it is not applied to Default.
+	Transform func(value interface{}) (interface{}, error)
+	// Default is used if the value at From cannot be got, if it's nil the rule will be skipped instead.
+	Default interface{}
+}
+
+// Mapping projects source documents into new map[string]interface{} documents, using a list of rules that are
+// parsed once, by Accessor.Compile, and may be applied many times, and concurrently.
+type Mapping struct {
+	accessor Accessor
+	rules    []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	from []string
+	to   []string
+}
+
+// Compile parses the keys of each rule, returning a Mapping that uses this accessor, or an error if any rule has a
+// key that does not parse to any properties.
+func (p Accessor) Compile(rules ...Rule) (*Mapping, error) {
+	mapping := &Mapping{
+		accessor: p,
+		rules:    make([]compiledRule, 0, len(rules)),
+	}
+
+	for _, rule := range rules {
+		compiled := compiledRule{
+			Rule: rule,
+			from: p.parser(rule.From),
+			to:   p.parser(rule.To),
+		}
+
+		if len(compiled.from) == 0 {
+			return nil, errors.New("no properties parsed from key: " + rule.From)
+		}
+
+		if len(compiled.to) == 0 {
+			return nil, errors.New("no properties parsed from key: " + rule.To)
+		}
+
+		mapping.rules = append(mapping.rules, compiled)
+	}
+
+	return mapping, nil
+}
+
+// Apply builds a new document from source, by applying each rule in order, returning an error if any transform
+// fails, or any value cannot be set. Values are deep copied into the result, so neither source, nor any Default, is
+// modified by later rules.
+func (m *Mapping) Apply(source interface{}) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+
+	for _, rule := range m.rules {
+		value, err := m.accessor.get(source, rule.from)
+
+		if err != nil {
+			if rule.Default == nil {
+				continue
+			}
+
+			value = rule.Default
+		} else if rule.Transform != nil {
+			if value, err = rule.Transform(value); err != nil {
+				return nil, fmt.Errorf("failed to transform '%s': %v", rule.From, err)
+			}
+		}
+
+		if err := m.accessor.create(result, rule.to, deepCopy(value)); err != nil {
+			return nil, fmt.Errorf("failed to set '%s': %v", rule.To, err)
+		}
+	}
+
+	return result, nil
+}
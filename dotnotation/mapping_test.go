@@ -0,0 +1,183 @@
+package dotnotation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestMapping_Apply(t *testing.T) {
+	mapping, err := Accessor{}.Compile(
+		Rule{
+			From: "customer.name",
+			To:   "user.profile.name",
+		},
+		Rule{
+			From: "customer.emails.0",
+			To:   "user.email",
+			Transform: func(value interface{}) (interface{}, error) {
+				return strings.ToLower(fmt.Sprint(value)), nil
+			},
+		},
+		Rule{
+			From:    "customer.country",
+			To:      "user.profile.country",
+			Default: "AU",
+		},
+		Rule{
+			From: "customer.missing",
+			To:   "user.missing",
+		},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	sources := []map[string]interface{}{
+		{
+			"customer": map[string]interface{}{
+				"name":   "Joe",
+				"emails": []interface{}{"JOE@EXAMPLE.COM"},
+			},
+		},
+		{
+			"customer": map[string]interface{}{
+				"name":    "Jane",
+				"emails":  []interface{}{"jane@example.com"},
+				"country": "NZ",
+			},
+		},
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"user": map[string]interface{}{
+				"email": "joe@example.com",
+				"profile": map[string]interface{}{
+					"name":    "Joe",
+					"country": "AU",
+				},
+			},
+		},
+		{
+			"user": map[string]interface{}{
+				"email": "jane@example.com",
+				"profile": map[string]interface{}{
+					"name":    "Jane",
+					"country": "NZ",
+				},
+			},
+		},
+	}
+
+	for i, source := range sources {
+		result, err := mapping.Apply(source)
+
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if diff := deep.Equal(expected[i], result); diff != nil {
+			t.Errorf("unexpected diff for source %d: %v", i, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestMapping_Apply_copies(t *testing.T) {
+	mapping, err := Accessor{}.Compile(
+		Rule{From: "a", To: "x", Default: map[string]interface{}{}},
+		Rule{From: "b", To: "x.c"},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	source := map[string]interface{}{
+		"a": map[string]interface{}{"one": 1},
+		"b": 2,
+	}
+
+	result, err := mapping.Apply(source)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"x": map[string]interface{}{"one": 1, "c": 2}}, result); diff != nil {
+		t.Errorf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"a": map[string]interface{}{"one": 1}, "b": 2}, source); diff != nil {
+		t.Errorf("expected source to be unchanged: %v", strings.Join(diff, ", "))
+	}
+
+	// the default must not retain values set by a previous apply
+	for i := 0; i < 2; i++ {
+		result, err := mapping.Apply(map[string]interface{}{"b": i})
+
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if diff := deep.Equal(map[string]interface{}{"x": map[string]interface{}{"c": i}}, result); diff != nil {
+			t.Errorf("unexpected diff for apply %d: %v", i, strings.Join(diff, ", "))
+		}
+	}
+
+	if result, err := mapping.Apply(map[string]interface{}{}); err != nil || len(result["x"].(map[string]interface{})) != 0 {
+		t.Errorf("unexpected result %v / error %v", result, err)
+	}
+}
+
+func TestMapping_Apply_errors(t *testing.T) {
+	source := map[string]interface{}{
+		"one": 1,
+	}
+
+	mapping, err := Accessor{}.Compile(Rule{
+		From: "one",
+		To:   "two",
+		Transform: func(value interface{}) (interface{}, error) {
+			return nil, errors.New("some error")
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := mapping.Apply(source); err == nil || err.Error() != "failed to transform 'one': some error" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	// cannot set a property on an int
+	mapping, err = Accessor{}.Compile(
+		Rule{From: "one", To: "two"},
+		Rule{From: "one", To: "two.three"},
+	)
+
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if _, err := mapping.Apply(source); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestAccessor_Compile_parserError(t *testing.T) {
+	accessor := Accessor{
+		Parser: func(key string) []string {
+			return nil
+		},
+	}
+
+	if _, err := accessor.Compile(Rule{From: "key", To: "key"}); err == nil || err.Error() != "no properties parsed from key: key" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
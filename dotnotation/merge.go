@@ -0,0 +1,36 @@
+package dotnotation
+
+import (
+	"errors"
+)
+
+// Merge recursively merges value into the value at key, within target, if both are map[string]interface{}, otherwise
+// value is set, as per Set. Merged maps are not copied, and should not be modified after this call.
+func (p Accessor) Merge(target interface{}, key string, value interface{}) error {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	return p.merge(target, properties, value)
+}
+
+// merge implements Merge, for a non-empty list of properties.
+func (p Accessor) merge(target interface{}, properties []string, value interface{}) error {
+	if src, ok := value.(map[string]interface{}); ok {
+		if existing, err := p.get(target, properties); err == nil {
+			if dst, ok := existing.(map[string]interface{}); ok {
+				for key, element := range src {
+					if err := p.merge(dst, []string{key}, element); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			}
+		}
+	}
+
+	return p.set(target, properties, value)
+}
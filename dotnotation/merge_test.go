@@ -0,0 +1,88 @@
+package dotnotation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestAccessor_Merge(t *testing.T) {
+	testCases := []struct {
+		name    string
+		target  interface{}
+		key     string
+		value   interface{}
+		success bool
+		outcome interface{}
+	}{
+		{
+			name: "nested maps",
+			target: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "one",
+					"pool": map[string]interface{}{"min": 1, "max": 2},
+				},
+			},
+			key: "db",
+			value: map[string]interface{}{
+				"port": 5432,
+				"pool": map[string]interface{}{"max": 3},
+			},
+			success: true,
+			outcome: map[string]interface{}{
+				"db": map[string]interface{}{
+					"host": "one",
+					"port": 5432,
+					"pool": map[string]interface{}{"min": 1, "max": 3},
+				},
+			},
+		},
+		{
+			name: "replace non-map",
+			target: map[string]interface{}{
+				"db": []interface{}{1},
+			},
+			key:     "db",
+			value:   map[string]interface{}{"host": "one"},
+			success: true,
+			outcome: map[string]interface{}{
+				"db": map[string]interface{}{"host": "one"},
+			},
+		},
+		{
+			name: "missing",
+			target: map[string]interface{}{
+				"db": map[string]interface{}{},
+			},
+			key:     "db.pool",
+			value:   map[string]interface{}{"min": 1},
+			success: true,
+			outcome: map[string]interface{}{
+				"db": map[string]interface{}{
+					"pool": map[string]interface{}{"min": 1},
+				},
+			},
+		},
+		{
+			name:    "missing parent",
+			target:  map[string]interface{}{},
+			key:     "db.pool",
+			value:   map[string]interface{}{"min": 1},
+			success: false,
+			outcome: map[string]interface{}{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := Accessor{}.Merge(testCase.target, testCase.key, testCase.value)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package dotnotation
+
+// GetterFunc is the signature of Accessor.Getter.
+type GetterFunc func(target interface{}, property string) (interface{}, error)
+
+// SetterFunc is the signature of Accessor.Setter.
+type SetterFunc func(target interface{}, property string, value interface{}) error
+
+// DeleterFunc is the signature of Accessor.Deleter.
+type DeleterFunc func(target interface{}, property string) error
+
+// Middleware intercepts calls to the Getter, Setter, and Deleter of an Accessor, see Accessor.Use. Each function
+// receives the next handler in the chain, which it may call, or not, and any nil function simply calls next.
+type Middleware struct {
+	Get    func(target interface{}, property string, next GetterFunc) (interface{}, error)
+	Set    func(target interface{}, property string, value interface{}, next SetterFunc) error
+	Delete func(target interface{}, property string, next DeleterFunc) error
+}
+
+// Use wraps the Getter, Setter, and Deleter (or their defaults, if nil) with each middleware, such that the first
+// middleware is called first. As each call to Use wraps the current handlers, middleware added by a later call to
+// Use is called before any added by an earlier call.
+func (p *Accessor) Use(middleware ...Middleware) {
+	getter := GetterFunc(p.Getter)
+	if getter == nil {
+		getter = DefaultGetter
+	}
+
+	setter := SetterFunc(p.Setter)
+	if setter == nil {
+		setter = DefaultSetter
+	}
+
+	deleter := DeleterFunc(p.Deleter)
+	if deleter == nil {
+		deleter = DefaultDeleter
+	}
+
+	for i := len(middleware) - 1; i >= 0; i-- {
+		getter = middleware[i].getter(getter)
+		setter = middleware[i].setter(setter)
+		deleter = middleware[i].deleter(deleter)
+	}
+
+	p.Getter = getter
+	p.Setter = setter
+	p.Deleter = deleter
+}
+
+func (m Middleware) getter(next GetterFunc) GetterFunc {
+	if m.Get == nil {
+		return next
+	}
+
+	return func(target interface{}, property string) (interface{}, error) {
+		return m.Get(target, property, next)
+	}
+}
+
+func (m Middleware) setter(next SetterFunc) SetterFunc {
+	if m.Set == nil {
+		return next
+	}
+
+	return func(target interface{}, property string, value interface{}) error {
+		return m.Set(target, property, value, next)
+	}
+}
+
+func (m Middleware) deleter(next DeleterFunc) DeleterFunc {
+	if m.Delete == nil {
+		return next
+	}
+
+	return func(target interface{}, property string) error {
+		return m.Delete(target, property, next)
+	}
+}
@@ -0,0 +1,108 @@
+package dotnotation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestAccessor_Use(t *testing.T) {
+	var calls []string
+
+	logger := func(name string) Middleware {
+		return Middleware{
+			Get: func(target interface{}, property string, next GetterFunc) (interface{}, error) {
+				calls = append(calls, name+" get "+property)
+				return next(target, property)
+			},
+			Set: func(target interface{}, property string, value interface{}, next SetterFunc) error {
+				calls = append(calls, fmt.Sprintf("%s set %s %v", name, property, value))
+				return next(target, property, value)
+			},
+			Delete: func(target interface{}, property string, next DeleterFunc) error {
+				calls = append(calls, name+" delete "+property)
+				return next(target, property)
+			},
+		}
+	}
+
+	redact := Middleware{
+		Get: func(target interface{}, property string, next GetterFunc) (interface{}, error) {
+			if property == "password" {
+				return "REDACTED", nil
+			}
+			return next(target, property)
+		},
+	}
+
+	var accessor Accessor
+
+	accessor.Use(logger("inner"), redact)
+	accessor.Use(logger("outer"))
+
+	target := map[string]interface{}{
+		"user": map[string]interface{}{
+			"password": "secret",
+		},
+	}
+
+	value, err := accessor.Get(target, "user.password")
+
+	if err != nil || value != "REDACTED" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if err := accessor.Set(target, "user.name", "joe"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := accessor.Delete(target, "user.name"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []string{
+		"outer get user",
+		"inner get user",
+		"outer get password",
+		"inner get password",
+		"outer get user",
+		"inner get user",
+		"outer set name joe",
+		"inner set name joe",
+		"outer get user",
+		"inner get user",
+		"outer delete name",
+		"inner delete name",
+	}
+
+	if diff := deep.Equal(expected, calls); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"user": map[string]interface{}{"password": "secret"}}, target); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}
+
+func TestAccessor_Use_existing(t *testing.T) {
+	accessor := Accessor{
+		Getter: func(target interface{}, property string) (interface{}, error) {
+			return property, nil
+		},
+	}
+
+	accessor.Use(Middleware{
+		Get: func(target interface{}, property string, next GetterFunc) (interface{}, error) {
+			value, err := next(target, property)
+			return strings.ToUpper(fmt.Sprint(value)), err
+		},
+	})
+
+	value, err := accessor.Get(nil, "one.two")
+
+	if err != nil || value != "TWO" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+}
@@ -0,0 +1,251 @@
+package dotnotation
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Move moves the value at the key from, to the key to, within target, replacing any existing value, as per Set.
+// Like JSON Patch, the value is deleted before it's set, so to is resolved after any following elements of a slice
+// have shifted left, and moving within the same slice inserts the value at to, rather than replacing the element.
+// If the value cannot be deleted, target is left unchanged, and if it cannot then be set, it's restored to it's
+// original location.
+func (p Accessor) Move(target interface{}, from string, to string) error {
+	fromProperties, toProperties, err := p.parseMove(from, to)
+	if err != nil {
+		return err
+	}
+
+	return p.move(target, fromProperties, toProperties)
+}
+
+// Copy sets the key to, within target, to a deep copy of the value at the key from. Copies are made of the slice and
+// map types supported by DefaultGetter, including those with one level of pointer indirection, and *Object, any other
+// values are shared.
+func (p Accessor) Copy(target interface{}, from string, to string) error {
+	fromProperties, toProperties, err := p.parseMove(from, to)
+	if err != nil {
+		return err
+	}
+
+	value, err := p.get(target, fromProperties)
+	if err != nil {
+		return err
+	}
+
+	return p.set(target, toProperties, deepCopy(value))
+}
+
+// Rename moves the value at key, within target, to the property name, within the same parent, which is intended for
+// renaming map keys.
+func (p Accessor) Rename(target interface{}, key string, name string) error {
+	properties := p.parser(key)
+
+	if len(properties) == 0 {
+		return errors.New("no properties parsed from key: " + key)
+	}
+
+	to := make([]string, len(properties))
+	copy(to, properties)
+	to[len(to)-1] = name
+
+	return p.move(target, properties, to)
+}
+
+func (p Accessor) parseMove(from string, to string) ([]string, []string, error) {
+	fromProperties := p.parser(from)
+
+	if len(fromProperties) == 0 {
+		return nil, nil, errors.New("no properties parsed from key: " + from)
+	}
+
+	toProperties := p.parser(to)
+
+	if len(toProperties) == 0 {
+		return nil, nil, errors.New("no properties parsed from key: " + to)
+	}
+
+	return fromProperties, toProperties, nil
+}
+
+// move implements Move, for non-empty lists of properties.
+func (p Accessor) move(target interface{}, from []string, to []string) error {
+	value, err := p.get(target, from)
+	if err != nil {
+		return err
+	}
+
+	if hasPrefix(from, to) {
+		if len(from) == len(to) {
+			// moving a value onto itself is a no-op
+			return nil
+		}
+
+		// the value at from will be replaced, along with the rest of the value at to
+		return p.set(target, to, value)
+	}
+
+	if hasPrefix(to, from) {
+		return errors.New("cannot move a value into itself")
+	}
+
+	// values deleted from a slice must be inserted to be restored, as the following elements will have shifted
+	parent := target
+	if len(from) > 1 {
+		parent, _ = p.get(target, from[:len(from)-1])
+	}
+
+	fromSlice := false
+	switch parent.(type) {
+	case []interface{}, *[]interface{}:
+		fromSlice = true
+	}
+
+	if err := p.delete(target, from); err != nil {
+		return err
+	}
+
+	if fromSlice && len(from) == len(to) && hasPrefix(to, from[:len(from)-1]) {
+		err = p.insert(target, to, value)
+	} else {
+		err = p.set(target, to, value)
+	}
+
+	if err != nil {
+		if fromSlice {
+			_ = p.insert(target, from, value)
+		} else {
+			_ = p.set(target, from, value)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// copyStringsMap returns a copy of m, including each slice of values.
+func copyStringsMap(m map[string][]string) map[string][]string {
+	if m == nil {
+		return nil
+	}
+
+	result := make(map[string][]string, len(m))
+	for key, values := range m {
+		result[key] = deepCopy(values).([]string)
+	}
+
+	return result
+}
+
+// hasPrefix returns true if properties starts with prefix.
+func hasPrefix(properties []string, prefix []string) bool {
+	if len(prefix) > len(properties) {
+		return false
+	}
+
+	for i, property := range prefix {
+		if properties[i] != property {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deepCopy returns a copy of value, recursively copying the types supported by DefaultGetter.
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+
+		result := make([]interface{}, len(v))
+		for i, element := range v {
+			result[i] = deepCopy(element)
+		}
+
+		return result
+
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+
+		result := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			result[key] = deepCopy(element)
+		}
+
+		return result
+
+	case map[interface{}]interface{}:
+		if v == nil {
+			return v
+		}
+
+		result := make(map[interface{}]interface{}, len(v))
+		for key, element := range v {
+			result[key] = deepCopy(element)
+		}
+
+		return result
+
+	case []string:
+		if v == nil {
+			return v
+		}
+
+		return append(make([]string, 0, len(v)), v...)
+
+	case map[string][]string:
+		return copyStringsMap(v)
+
+	case url.Values:
+		return url.Values(copyStringsMap(v))
+
+	case http.Header:
+		return http.Header(copyStringsMap(v))
+
+	case *[]interface{}:
+		if v == nil {
+			return v
+		}
+
+		result := deepCopy(*v).([]interface{})
+		return &result
+
+	case *map[string]interface{}:
+		if v == nil {
+			return v
+		}
+
+		result := deepCopy(*v).(map[string]interface{})
+		return &result
+
+	case *map[interface{}]interface{}:
+		if v == nil {
+			return v
+		}
+
+		result := deepCopy(*v).(map[interface{}]interface{})
+		return &result
+
+	case *Object:
+		if v == nil {
+			return v
+		}
+
+		result := &Object{keys: v.Keys(), values: make(map[string]interface{}, len(v.values))}
+		for _, key := range v.keys {
+			result.values[key] = deepCopy(v.values[key])
+		}
+
+		return result
+
+	default:
+		return value
+	}
+}
@@ -0,0 +1,330 @@
+package dotnotation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+type moveTestCase struct {
+	name     string
+	accessor Accessor
+	target   interface{}
+	from     string
+	to       string
+	success  bool
+	outcome  interface{}
+}
+
+func TestAccessor_Move(t *testing.T) {
+	testCases := []moveTestCase{
+		{
+			name: "between maps",
+			target: map[string]interface{}{
+				"one": map[string]interface{}{"a": 1},
+				"two": map[string]interface{}{},
+			},
+			from:    "one.a",
+			to:      "two.b",
+			success: true,
+			outcome: map[string]interface{}{
+				"one": map[string]interface{}{},
+				"two": map[string]interface{}{"b": 1},
+			},
+		},
+		{
+			name: "replace existing",
+			target: map[string]interface{}{
+				"one": 1,
+				"two": 2,
+			},
+			from:    "one",
+			to:      "two",
+			success: true,
+			outcome: map[string]interface{}{
+				"two": 1,
+			},
+		},
+		{
+			name: "slice to map",
+			target: map[string]interface{}{
+				"items": []interface{}{1, 2, 3},
+			},
+			from:    "items.1",
+			to:      "two",
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 3},
+				"two":   2,
+			},
+		},
+		{
+			name: "map to slice append",
+			target: map[string]interface{}{
+				"items": []interface{}{1},
+				"two":   2,
+			},
+			from:    "two",
+			to:      "items.1",
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{1, 2},
+			},
+		},
+		{
+			name: "missing source",
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			from:    "two",
+			to:      "three",
+			success: false,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+		{
+			name: "missing destination parent",
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			from:    "one",
+			to:      "two.three",
+			success: false,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+		{
+			name: "onto itself",
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			from:    "one",
+			to:      "one",
+			success: true,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+		{
+			name: "into itself",
+			target: map[string]interface{}{
+				"one": map[string]interface{}{},
+			},
+			from:    "one",
+			to:      "one.two",
+			success: false,
+			outcome: map[string]interface{}{
+				"one": map[string]interface{}{},
+			},
+		},
+		{
+			name: "onto parent",
+			target: map[string]interface{}{
+				"one": map[string]interface{}{"two": 2},
+			},
+			from:    "one.two",
+			to:      "one",
+			success: true,
+			outcome: map[string]interface{}{
+				"one": 2,
+			},
+		},
+		{
+			name: "forward within a slice",
+			target: map[string]interface{}{
+				"items": []interface{}{"a", "b", "c"},
+			},
+			from:    "items.0",
+			to:      "items.2",
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{"b", "c", "a"},
+			},
+		},
+		{
+			name: "backward within a slice",
+			target: map[string]interface{}{
+				"items": []interface{}{"a", "b", "c"},
+			},
+			from:    "items.2",
+			to:      "items.0",
+			success: true,
+			outcome: map[string]interface{}{
+				"items": []interface{}{"c", "a", "b"},
+			},
+		},
+		{
+			name: "out of range within a slice",
+			target: map[string]interface{}{
+				"items": []interface{}{"a", "b", "c"},
+			},
+			from:    "items.0",
+			to:      "items.3",
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{"a", "b", "c"},
+			},
+		},
+		{
+			name: "set failure restores slice element",
+			target: map[string]interface{}{
+				"items": []interface{}{"a", "b", "c"},
+			},
+			from:    "items.1",
+			to:      "two.three",
+			success: false,
+			outcome: map[string]interface{}{
+				"items": []interface{}{"a", "b", "c"},
+			},
+		},
+		{
+			name: "delete failure leaves new value unset",
+			accessor: Accessor{
+				Deleter: func(target interface{}, property string) error {
+					if property == "one" {
+						return errors.New("some error")
+					}
+					return DefaultDeleter(target, property)
+				},
+			},
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			from:    "one",
+			to:      "two",
+			success: false,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+		{
+			name: "delete failure leaves existing value",
+			accessor: Accessor{
+				Deleter: func(target interface{}, property string) error {
+					if property == "one" {
+						return errors.New("some error")
+					}
+					return DefaultDeleter(target, property)
+				},
+			},
+			target: map[string]interface{}{
+				"one": 1,
+				"two": 2,
+			},
+			from:    "one",
+			to:      "two",
+			success: false,
+			outcome: map[string]interface{}{
+				"one": 1,
+				"two": 2,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.accessor.Move(testCase.target, testCase.from, testCase.to)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Copy(t *testing.T) {
+	target := map[string]interface{}{
+		"one": map[string]interface{}{
+			"items": []interface{}{1, map[string]interface{}{"a": 2}},
+		},
+	}
+
+	if err := (Accessor{}).Copy(target, "one", "two"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// modifying the copy must not affect the original
+	if err := (Accessor{}).Set(target, "two.items.1.a", 3); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := (Accessor{}).Set(target, "two.items.2", 4); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"one": map[string]interface{}{
+			"items": []interface{}{1, map[string]interface{}{"a": 2}},
+		},
+		"two": map[string]interface{}{
+			"items": []interface{}{1, map[string]interface{}{"a": 3}, 4},
+		},
+	}
+
+	if diff := deep.Equal(expected, target); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	if err := (Accessor{}).Copy(target, "three", "four"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestAccessor_Rename(t *testing.T) {
+	testCases := []moveTestCase{
+		{
+			name: "nested",
+			target: map[string]interface{}{
+				"one": map[string]interface{}{"a": 1, "b": 2},
+			},
+			from:    "one.a",
+			to:      "c",
+			success: true,
+			outcome: map[string]interface{}{
+				"one": map[string]interface{}{"c": 1, "b": 2},
+			},
+		},
+		{
+			name: "same name",
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			from:    "one",
+			to:      "one",
+			success: true,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+		{
+			name: "missing",
+			target: map[string]interface{}{
+				"one": 1,
+			},
+			from:    "two",
+			to:      "three",
+			success: false,
+			outcome: map[string]interface{}{
+				"one": 1,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.accessor.Rename(testCase.target, testCase.from, testCase.to)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, testCase.target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package dotnotation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Object is a JSON object that preserves the order of it's members, which is supported by the default handlers via
+// PropertyGetter, PropertySetter, PropertyDeleter, and PropertyLister, where setting a new member adds it at the
+// end, and it's marshalled as JSON in order. Use DecodeOrdered to decode objects as *Object values.
+// The zero value is an empty object, and it must be used via a pointer.
+type Object struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// Len returns the number of members.
+func (o *Object) Len() int {
+	return len(o.keys)
+}
+
+// Keys returns the keys of the members, in order.
+func (o *Object) Keys() []string {
+	return append([]string(nil), o.keys...)
+}
+
+// Get returns the value of the member key, and true, or false if there is no such member.
+func (o *Object) Get(key string) (interface{}, bool) {
+	value, ok := o.values[key]
+	return value, ok
+}
+
+// Set replaces the value of the member key, in it's existing position, or adds it as the last member.
+func (o *Object) Set(key string, value interface{}) {
+	if o.values == nil {
+		o.values = make(map[string]interface{})
+	}
+
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+
+	o.values[key] = value
+}
+
+// Delete removes the member key, if it exists, preserving the order of the other members.
+func (o *Object) Delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+
+	delete(o.values, key)
+
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// GetProperty implements PropertyGetter.
+func (o *Object) GetProperty(property string) (interface{}, error) {
+	value, ok := o.Get(property)
+
+	if !ok {
+		return nil, fmt.Errorf("cannot get non-existent property '%s' on an object", property)
+	}
+
+	return value, nil
+}
+
+// SetProperty implements PropertySetter.
+func (o *Object) SetProperty(property string, value interface{}) error {
+	o.Set(property, value)
+	return nil
+}
+
+// DeleteProperty implements PropertyDeleter.
+func (o *Object) DeleteProperty(property string) error {
+	if _, ok := o.Get(property); !ok {
+		return fmt.Errorf("cannot delete non-existent property '%s' on an object", property)
+	}
+
+	o.Delete(property)
+
+	return nil
+}
+
+// ListProperties implements PropertyLister.
+func (o *Object) ListProperties() []string {
+	return o.Keys()
+}
+
+// MarshalJSON encodes the members in order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	buffer.WriteByte('{')
+
+	for i, key := range o.keys {
+		if i != 0 {
+			buffer.WriteByte(',')
+		}
+
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(name)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+
+	buffer.WriteByte('}')
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the members, decoding any nested objects as *Object values, see DecodeOrdered. As per
+// encoding/json, null is a no-op.
+func (o *Object) UnmarshalJSON(data []byte) error {
+	value, err := DecodeOrdered(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	object, ok := value.(*Object)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal %s into an object", data)
+	}
+
+	*o = *object
+
+	return nil
+}
+
+// DecodeOrdered reads a single JSON value from r, like encoding/json, except objects are decoded as *Object values,
+// which preserve the order of their members, where duplicate keys keep their first position, and the last value.
+func DecodeOrdered(r io.Reader) (interface{}, error) {
+	return decodeOrdered(json.NewDecoder(r))
+}
+
+func decodeOrdered(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := &Object{}
+
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			key, ok := token.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected token %v", token)
+			}
+
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			object.Set(key, value)
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return object, nil
+
+	case json.Delim('['):
+		slice := make([]interface{}, 0)
+
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+
+			slice = append(slice, value)
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+
+		return slice, nil
+
+	default:
+		return token, nil
+	}
+}
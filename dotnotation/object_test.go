@@ -0,0 +1,144 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDecodeOrdered(t *testing.T) {
+	const input = `{"z": 1, "a": {"y": [true, {"c": null, "b": "x"}], "x": 2}, "m": [], "z": 3}`
+
+	value, err := DecodeOrdered(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := value.(*Object)
+
+	if diff := deep.Equal([]string{"z", "a", "m"}, object.Keys()); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+
+	if value, err := Get(object, "a.y.1.b"); err != nil || value != "x" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	b, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"z":3,"a":{"y":[true,{"c":null,"b":"x"}],"x":2},"m":[]}`; string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+
+	for i, input := range []string{``, `{"one": }`, `{"one": [1, 2}`, `[1, 2`} {
+		if _, err := DecodeOrdered(strings.NewReader(input)); err == nil {
+			t.Errorf("test case %d expected an error", i)
+		}
+	}
+}
+
+func TestObject_accessor(t *testing.T) {
+	var object Object
+
+	if err := json.Unmarshal([]byte(`{"name": "app", "db": {"port": 5432, "host": "localhost"}, "tags": ["a"]}`), &object); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(&object, "db.host", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(&object, "db.name", "app"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Default().Delete(&object, "name"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Default().Append(&object, "tags", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Default().Insert(&object, "tags.0", "z"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(&object, "name", "last"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Get(&object, "missing"); err == nil || err.Error() != "cannot get non-existent property 'missing' on an object" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	b, err := json.Marshal(&object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"db":{"port":5432,"host":"example.com","name":"app"},"tags":["z","a","b"],"name":"last"}`; string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+
+	if err := json.Unmarshal([]byte(`[]`), &object); err == nil || err.Error() != "cannot unmarshal [] into an object" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(`null`), &object); err != nil || object.Len() != 3 {
+		t.Fatalf("unexpected length %d / error %v", object.Len(), err)
+	}
+
+	if err := Default().Delete(&object, "missing"); err == nil || err.Error() != "cannot delete non-existent property 'missing' on an object" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if omitted, err := Default().Omit(&object, "db.port", "db.missing"); err != nil || object.Len() != 3 {
+		t.Fatalf("unexpected result %v / error %v", omitted, err)
+	}
+}
+
+func TestObject_copy(t *testing.T) {
+	value, err := DecodeOrdered(strings.NewReader(`{"b": {"one": 1}, "a": 2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := value.(*Object)
+
+	if err := Default().Copy(object, "b", "c"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(object, "c.one", "changed"); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `{"b":{"one":1},"a":2,"c":{"one":"changed"}}`; string(b) != expected {
+		t.Fatalf("expected %s, got %s", expected, b)
+	}
+
+	snapshot := NewSnapshot(Accessor{}, object)
+
+	if _, err := snapshot.Apply(Operation{Key: "b.one", Value: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := Get(object, "b.one"); value != float64(1) {
+		t.Fatalf("unexpected value %v", value)
+	}
+
+	if value, _ := snapshot.Get("b.one"); value != 3 {
+		t.Fatalf("unexpected value %v", value)
+	}
+}
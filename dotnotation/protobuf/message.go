@@ -0,0 +1,554 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/joeycumines/go-dotnotation/dotnotation"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RepeatedField is a repeated field of a message, as returned by MessageGetter, which implements the
+// dotnotation.PropertyGetter, dotnotation.PropertySetter, dotnotation.PropertyDeleter, and
+// dotnotation.PropertyLister interfaces, accessing elements by index. The field is only made mutable if it's modified.
+type RepeatedField struct {
+	Message protoreflect.Message
+	Field   protoreflect.FieldDescriptor
+}
+
+// MapField is a map field of a message, as returned by MessageGetter, which implements the same interfaces as
+// RepeatedField, accessing entries by the string form of their key.
+type MapField struct {
+	Message protoreflect.Message
+	Field   protoreflect.FieldDescriptor
+}
+
+// MessageGetter returns the value of the field property of message, by JSON or proto name. Repeated and map fields are
+// returned as RepeatedField and MapField, message fields as a proto.Message, or nil if they are not set, enums as the
+// name of their value, if it's defined, and other fields as their Go type, e.g. int32.
+func MessageGetter(message protoreflect.Message, property string) (interface{}, error) {
+	field, err := messageField(message, "get", property)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case field.IsList():
+		return RepeatedField{Message: message, Field: field}, nil
+
+	case field.IsMap():
+		return MapField{Message: message, Field: field}, nil
+
+	case field.Message() != nil && !message.Has(field):
+		return nil, nil
+	}
+
+	return fromProtoValue(field, message.Get(field)), nil
+}
+
+// MessageSetter sets the field property of message, by JSON or proto name, to value, clearing it if value is nil.
+// Values are converted to the type of the field, where numbers must fit, enums may be set by name or number,
+// repeated fields from a []interface{}, and map fields from a map[string]interface{}. Messages are copied, if they are
+// of the same type, otherwise they are converted via encoding/json and protojson.
+func MessageSetter(message protoreflect.Message, property string, value interface{}) error {
+	field, err := messageField(message, "set", property)
+	if err != nil {
+		return err
+	}
+
+	if value == nil {
+		message.Clear(field)
+		return nil
+	}
+
+	var result protoreflect.Value
+
+	switch {
+	case field.IsList():
+		result, err = toProtoList(message.NewField(field).List(), field, value)
+
+	case field.IsMap():
+		result, err = toProtoMap(message.NewField(field).Map(), field, value)
+
+	default:
+		result, err = toProtoValue(field, func() protoreflect.Value { return message.NewField(field) }, value)
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot set field '%s': %v", property, err)
+	}
+
+	message.Set(field, result)
+
+	return nil
+}
+
+// MessageDeleter clears the field property of message, by JSON or proto name.
+func MessageDeleter(message protoreflect.Message, property string) error {
+	field, err := messageField(message, "delete", property)
+	if err != nil {
+		return err
+	}
+
+	message.Clear(field)
+
+	return nil
+}
+
+// List returns the list, which must not be modified, unless the field is set.
+func (f RepeatedField) List() protoreflect.List {
+	return f.Message.Get(f.Field).List()
+}
+
+// GetProperty implements dotnotation.PropertyGetter.
+func (f RepeatedField) GetProperty(property string) (interface{}, error) {
+	list := f.List()
+
+	i, err := dotnotation.ParseIndex(property)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot get non-integer property '%s' on a repeated field", property)
+	}
+
+	if i < 0 || i >= list.Len() {
+		return nil, fmt.Errorf("cannot get out of range property '%s' on a repeated field", property)
+	}
+
+	return fromProtoValue(f.Field, list.Get(i)), nil
+}
+
+// SetProperty implements dotnotation.PropertySetter, appending if property is equal to the length.
+func (f RepeatedField) SetProperty(property string, value interface{}) error {
+	i, err := dotnotation.ParseIndex(property)
+
+	if err != nil {
+		return fmt.Errorf("cannot set non-integer property '%s' on a repeated field", property)
+	}
+
+	if i < 0 || i > f.List().Len() {
+		return fmt.Errorf("cannot set out of range property '%s' on a repeated field", property)
+	}
+
+	list := f.Message.Mutable(f.Field).List()
+
+	element, err := toProtoValue(f.Field, list.NewElement, value)
+	if err != nil {
+		return fmt.Errorf("cannot set property '%s': %v", property, err)
+	}
+
+	if i == list.Len() {
+		list.Append(element)
+	} else {
+		list.Set(i, element)
+	}
+
+	return nil
+}
+
+// DeleteProperty implements dotnotation.PropertyDeleter, shifting any following elements.
+func (f RepeatedField) DeleteProperty(property string) error {
+	i, err := dotnotation.ParseIndex(property)
+
+	if err != nil {
+		return fmt.Errorf("cannot delete non-integer property '%s' on a repeated field", property)
+	}
+
+	if i < 0 || i >= f.List().Len() {
+		return fmt.Errorf("cannot delete out of range property '%s' on a repeated field", property)
+	}
+
+	list := f.Message.Mutable(f.Field).List()
+
+	for ; i < list.Len()-1; i++ {
+		list.Set(i, list.Get(i+1))
+	}
+
+	list.Truncate(list.Len() - 1)
+
+	return nil
+}
+
+// ListProperties implements dotnotation.PropertyLister.
+func (f RepeatedField) ListProperties() []string {
+	properties := make([]string, f.List().Len())
+	for i := range properties {
+		properties[i] = strconv.Itoa(i)
+	}
+	return properties
+}
+
+// Map returns the map, which must not be modified, unless the field is set.
+func (f MapField) Map() protoreflect.Map {
+	return f.Message.Get(f.Field).Map()
+}
+
+// GetProperty implements dotnotation.PropertyGetter.
+func (f MapField) GetProperty(property string) (interface{}, error) {
+	key, err := toProtoMapKey(f.Field.MapKey(), property)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get invalid property '%s' on a map field: %v", property, err)
+	}
+
+	value := f.Map().Get(key)
+
+	if !value.IsValid() {
+		return nil, fmt.Errorf("cannot get non-existent property '%s' on a map field", property)
+	}
+
+	return fromProtoValue(f.Field.MapValue(), value), nil
+}
+
+// SetProperty implements dotnotation.PropertySetter.
+func (f MapField) SetProperty(property string, value interface{}) error {
+	key, err := toProtoMapKey(f.Field.MapKey(), property)
+	if err != nil {
+		return fmt.Errorf("cannot set invalid property '%s' on a map field: %v", property, err)
+	}
+
+	m := f.Message.Mutable(f.Field).Map()
+
+	element, err := toProtoValue(f.Field.MapValue(), m.NewValue, value)
+	if err != nil {
+		return fmt.Errorf("cannot set property '%s': %v", property, err)
+	}
+
+	m.Set(key, element)
+
+	return nil
+}
+
+// DeleteProperty implements dotnotation.PropertyDeleter.
+func (f MapField) DeleteProperty(property string) error {
+	key, err := toProtoMapKey(f.Field.MapKey(), property)
+	if err != nil {
+		return fmt.Errorf("cannot delete invalid property '%s' on a map field: %v", property, err)
+	}
+
+	if !f.Map().Has(key) {
+		return fmt.Errorf("cannot delete non-existent property '%s' on a map field", property)
+	}
+
+	f.Message.Mutable(f.Field).Map().Clear(key)
+
+	return nil
+}
+
+// ListProperties implements dotnotation.PropertyLister, returning the sorted keys.
+func (f MapField) ListProperties() []string {
+	var properties []string
+
+	f.Map().Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		properties = append(properties, key.String())
+		return true
+	})
+
+	sort.Strings(properties)
+
+	return properties
+}
+
+// messageField returns the field of message with the JSON or proto name property, or an error including verb.
+func messageField(message protoreflect.Message, verb string, property string) (protoreflect.FieldDescriptor, error) {
+	fields := message.Descriptor().Fields()
+
+	if field := fields.ByJSONName(property); field != nil {
+		return field, nil
+	}
+
+	if field := fields.ByName(protoreflect.Name(property)); field != nil {
+		return field, nil
+	}
+
+	return nil, fmt.Errorf("cannot %s unknown field '%s' on message %s", verb, property, message.Descriptor().FullName())
+}
+
+// fromProtoValue returns the Go value of a singular value of field, see MessageGetter.
+func fromProtoValue(field protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return value.Message().Interface()
+
+	case protoreflect.EnumKind:
+		if v := field.Enum().Values().ByNumber(value.Enum()); v != nil {
+			return string(v.Name())
+		}
+		return int32(value.Enum())
+
+	default:
+		return value.Interface()
+	}
+}
+
+// toProtoList converts value, which must be a []interface{}, to the repeated field, using list.
+func toProtoList(list protoreflect.List, field protoreflect.FieldDescriptor, value interface{}) (protoreflect.Value, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return protoreflect.Value{}, fmt.Errorf("cannot convert %T to a repeated field", value)
+	}
+
+	for i, v := range values {
+		element, err := toProtoValue(field, list.NewElement, v)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("index %d: %v", i, err)
+		}
+
+		list.Append(element)
+	}
+
+	return protoreflect.ValueOfList(list), nil
+}
+
+// toProtoMap converts value, which must be a map[string]interface{}, to the map field, using m.
+func toProtoMap(m protoreflect.Map, field protoreflect.FieldDescriptor, value interface{}) (protoreflect.Value, error) {
+	values, ok := value.(map[string]interface{})
+	if !ok {
+		return protoreflect.Value{}, fmt.Errorf("cannot convert %T to a map field", value)
+	}
+
+	for k, v := range values {
+		key, err := toProtoMapKey(field.MapKey(), k)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("key %s: %v", k, err)
+		}
+
+		element, err := toProtoValue(field.MapValue(), m.NewValue, v)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("key %s: %v", k, err)
+		}
+
+		m.Set(key, element)
+	}
+
+	return protoreflect.ValueOfMap(m), nil
+}
+
+// toProtoMapKey parses the string form of a key of the map field with the key descriptor field, which must be in
+// canonical form, e.g. "1", not "01" or "+1", so that each key is addressed by exactly one property.
+func toProtoMapKey(field protoreflect.FieldDescriptor, key string) (protoreflect.MapKey, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(key).MapKey(), nil
+
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(key)
+		if err != nil || key != strconv.FormatBool(b) {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid bool '%s'", key)
+		}
+		return protoreflect.ValueOfBool(b).MapKey(), nil
+
+	default:
+		value, err := toProtoValue(field, nil, json.Number(key))
+		if err != nil {
+			return protoreflect.MapKey{}, err
+		}
+		if key != value.MapKey().String() {
+			return protoreflect.MapKey{}, fmt.Errorf("invalid %s '%s'", field.Kind(), key)
+		}
+		return value.MapKey(), nil
+	}
+}
+
+// toProtoValue converts value to a singular value of field, where newMessage is used to create messages.
+func toProtoValue(field protoreflect.FieldDescriptor, newMessage func() protoreflect.Value, value interface{}) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		result := newMessage()
+
+		if err := toProtoMessage(result.Message(), value); err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return result, nil
+
+	case protoreflect.EnumKind:
+		if name, ok := value.(string); ok {
+			v := field.Enum().Values().ByName(protoreflect.Name(name))
+			if v == nil {
+				return protoreflect.Value{}, fmt.Errorf("unknown value '%s' for enum %s", name, field.Enum().FullName())
+			}
+			return protoreflect.ValueOfEnum(v.Number()), nil
+		}
+
+		i, err := toInteger(value, 32)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(i)), nil
+
+	case protoreflect.BoolKind:
+		if b, ok := value.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+
+	case protoreflect.StringKind:
+		if s, ok := value.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+
+	case protoreflect.BytesKind:
+		switch v := value.(type) {
+		case []byte:
+			return protoreflect.ValueOfBytes(v), nil
+		case string:
+			return protoreflect.ValueOfBytes([]byte(v)), nil
+		}
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		i, err := toInteger(value, 32)
+		return protoreflect.ValueOfInt32(int32(i)), err
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		i, err := toInteger(value, 64)
+		return protoreflect.ValueOfInt64(i), err
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		u, err := toUnsigned(value, 32)
+		return protoreflect.ValueOfUint32(uint32(u)), err
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		u, err := toUnsigned(value, 64)
+		return protoreflect.ValueOfUint64(u), err
+
+	case protoreflect.FloatKind:
+		f, err := toFloat(value)
+		if err == nil && f == f && !math.IsInf(f, 0) && math.Abs(f) > math.MaxFloat32 {
+			err = fmt.Errorf("cannot convert %v to float32 without loss", value)
+		}
+		return protoreflect.ValueOfFloat32(float32(f)), err
+
+	case protoreflect.DoubleKind:
+		f, err := toFloat(value)
+		return protoreflect.ValueOfFloat64(f), err
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("cannot convert %T to %s", value, field.Kind())
+}
+
+// toProtoMessage populates message, which must be empty, from value, see MessageSetter.
+func toProtoMessage(message protoreflect.Message, value interface{}) error {
+	if v, ok := value.(proto.Message); ok && v.ProtoReflect().Descriptor().FullName() == message.Descriptor().FullName() {
+		proto.Merge(message.Interface(), v)
+		return nil
+	}
+
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return protojson.Unmarshal(b, message.Interface())
+}
+
+// toInteger converts the number value, which may be any Go number, json.Number, or integral float, to an integer
+// that fits within bits.
+func toInteger(value interface{}, bits int) (int64, error) {
+	v := reflect.ValueOf(value)
+
+	var i int64
+
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		i = v.Int()
+
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		if v.Uint() > math.MaxInt64 {
+			return 0, fmt.Errorf("cannot convert %v to int%d without loss", value, bits)
+		}
+		i = int64(v.Uint())
+
+	default:
+		if n, ok := value.(json.Number); ok {
+			if parsed, err := strconv.ParseInt(n.String(), 10, 64); err == nil {
+				i = parsed
+				break
+			}
+		}
+
+		f, err := toFloat(value)
+		if err != nil {
+			return 0, err
+		}
+
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, fmt.Errorf("cannot convert %v to int%d without loss", value, bits)
+		}
+
+		i = int64(f)
+	}
+
+	if bits < 64 && (i < -1<<uint(bits-1) || i >= 1<<uint(bits-1)) {
+		return 0, fmt.Errorf("cannot convert %v to int%d without loss", value, bits)
+	}
+
+	return i, nil
+}
+
+// toUnsigned converts the number value, as per toInteger, to an unsigned integer that fits within bits.
+func toUnsigned(value interface{}, bits int) (uint64, error) {
+	v := reflect.ValueOf(value)
+
+	var u uint64
+
+	switch {
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		u = v.Uint()
+
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		if v.Int() < 0 {
+			return 0, fmt.Errorf("cannot convert %v to uint%d without loss", value, bits)
+		}
+		u = uint64(v.Int())
+
+	default:
+		if n, ok := value.(json.Number); ok {
+			if parsed, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+				u = parsed
+				break
+			}
+		}
+
+		f, err := toFloat(value)
+		if err != nil {
+			return 0, err
+		}
+
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return 0, fmt.Errorf("cannot convert %v to uint%d without loss", value, bits)
+		}
+
+		u = uint64(f)
+	}
+
+	if bits < 64 && u >= 1<<uint(bits) {
+		return 0, fmt.Errorf("cannot convert %v to uint%d without loss", value, bits)
+	}
+
+	return u, nil
+}
+
+// toFloat converts the number value, which may be any Go number, or json.Number, to a float64.
+func toFloat(value interface{}) (float64, error) {
+	if n, ok := value.(json.Number); ok {
+		return n.Float64()
+	}
+
+	v := reflect.ValueOf(value)
+
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return float64(v.Int()), nil
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float(), nil
+	default:
+		return 0, fmt.Errorf("cannot convert %T to a number", value)
+	}
+}
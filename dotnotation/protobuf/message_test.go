@@ -0,0 +1,205 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/joeycumines/go-dotnotation/dotnotation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+func TestMessage(t *testing.T) {
+	message := &typepb.Type{
+		Name: "Example",
+		Fields: []*typepb.Field{
+			{Name: "id", Number: 1, Kind: typepb.Field_TYPE_INT64},
+		},
+		Oneofs: []string{"choice"},
+	}
+
+	accessor := dotnotation.Accessor{Getter: Getter, Setter: Setter, Deleter: Deleter}
+
+	type TestCase struct {
+		Key   string
+		Value interface{}
+		Err   string
+	}
+
+	testCases := []TestCase{
+		{Key: "name", Value: "Example"},
+		{Key: "fields.0.number", Value: int32(1)},
+		{Key: "fields.0.kind", Value: "TYPE_INT64"},
+		{Key: "fields.0.packed", Value: false},
+		{Key: "oneofs.0", Value: "choice"},
+		{Key: "source_context", Value: nil},
+		{Key: "sourceContext", Value: nil},
+		{Key: "syntax", Value: "SYNTAX_PROTO2"},
+		{Key: "missing", Err: "cannot get unknown field 'missing' on message google.protobuf.Type"},
+		{Key: "fields.1", Err: "cannot get out of range property '1' on a repeated field"},
+		{Key: "fields.one", Err: "cannot get non-integer property 'one' on a repeated field"},
+	}
+
+	for i, testCase := range testCases {
+		value, err := accessor.Get(message, testCase.Key)
+
+		if (err == nil) != (testCase.Err == "") || (err != nil && err.Error() != testCase.Err) {
+			t.Errorf("test case %d expected error %q, got %v", i, testCase.Err, err)
+		}
+
+		if diff := deep.Equal(testCase.Value, value); diff != nil {
+			t.Errorf("test case %d unexpected value: %s", i, strings.Join(diff, ", "))
+		}
+	}
+
+	if properties, err := accessor.Get(message, "fields"); err != nil || strings.Join(properties.(RepeatedField).ListProperties(), ",") != "0" {
+		t.Fatalf("unexpected properties %v / error %v", properties, err)
+	}
+
+	sets := []struct {
+		key   string
+		value interface{}
+	}{
+		{"fields.0.number", json.Number("3")},
+		{"fields.0.kind", "TYPE_STRING"},
+		{"fields.0.json_name", "identifier"},
+		{"fields.1", map[string]interface{}{"name": "extra", "number": 2, "kind": "TYPE_BOOL"}},
+		{"sourceContext", map[string]interface{}{"file_name": "example.proto"}},
+		{"source_context.fileName", "other.proto"},
+		{"syntax", 1},
+		{"oneofs", []interface{}{"a", "b"}},
+		{"options", []interface{}{}},
+	}
+
+	for _, set := range sets {
+		if err := accessor.Set(message, set.key, set.value); err != nil {
+			t.Fatalf("%s: %v", set.key, err)
+		}
+	}
+
+	if err := accessor.Delete(message, "oneofs.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Delete(message, "name"); err != nil {
+		t.Fatal(err)
+	}
+
+	errors := []struct {
+		key   string
+		value interface{}
+		err   string
+	}{
+		{"fields.0.number", 1.5, "cannot set field 'number': cannot convert 1.5 to int32 without loss"},
+		{"fields.0.number", int64(1) << 40, "cannot set field 'number': cannot convert 1099511627776 to int32 without loss"},
+		{"fields.0.kind", "TYPE_MISSING", "cannot set field 'kind': unknown value 'TYPE_MISSING' for enum google.protobuf.Field.Kind"},
+		{"fields.0.packed", "true", "cannot set field 'packed': cannot convert string to bool"},
+		{"fields.3", map[string]interface{}{}, "cannot set out of range property '3' on a repeated field"},
+		{"oneofs", "a", "cannot set field 'oneofs': cannot convert string to a repeated field"},
+		{"oneofs.0", 1, "cannot set property '0': cannot convert int to string"},
+		{"missing", 1, "cannot set unknown field 'missing' on message google.protobuf.Type"},
+	}
+
+	for _, e := range errors {
+		if err := accessor.Set(message, e.key, e.value); err == nil || err.Error() != e.err {
+			t.Errorf("%s: unexpected error %v", e.key, err)
+		}
+	}
+
+	expected := &typepb.Type{
+		Fields: []*typepb.Field{
+			{Name: "id", Number: 3, Kind: typepb.Field_TYPE_STRING, JsonName: "identifier"},
+			{Name: "extra", Number: 2, Kind: typepb.Field_TYPE_BOOL},
+		},
+		Oneofs:        []string{"b"},
+		SourceContext: &sourcecontextpb.SourceContext{FileName: "other.proto"},
+		Syntax:        typepb.Syntax_SYNTAX_PROTO3,
+	}
+
+	if !proto.Equal(expected, message) {
+		t.Fatalf("unexpected message %v", message)
+	}
+
+	if err := accessor.Set(message, "sourceContext", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if message.SourceContext != nil {
+		t.Fatalf("unexpected source context %v", message.SourceContext)
+	}
+}
+
+func TestMapField(t *testing.T) {
+	message := (&structpb.Struct{}).ProtoReflect()
+
+	accessor := dotnotation.Accessor{Getter: Getter, Setter: Setter, Deleter: Deleter}
+
+	if err := accessor.Set(message, "fields", map[string]interface{}{"a": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Set(message, "fields.b", []interface{}{"c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Set(message, "fields.d", structpb.NewBoolValue(true)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Delete(message, "fields.a"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Delete(message, "fields.a"); err == nil || err.Error() != "cannot delete non-existent property 'a' on a map field" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if value, err := accessor.Get(message, "fields.b.0"); err != nil || value != "c" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if _, err := accessor.Get(message, "fields.a"); err == nil || err.Error() != "cannot get non-existent property 'a' on a map field" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if value, err := accessor.Get(message, "fields"); err != nil || strings.Join(value.(MapField).ListProperties(), ",") != "b,d" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	expected := map[string]interface{}{"b": []interface{}{"c"}, "d": true}
+
+	if diff := deep.Equal(expected, message.Interface().(*structpb.Struct).AsMap()); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+}
+
+func TestToProtoMapKey(t *testing.T) {
+	// the key type of google.protobuf.Struct is string, so the other kinds are tested via the descriptors of fields
+	fields := (&typepb.Field{}).ProtoReflect().Descriptor().Fields()
+
+	if key, err := toProtoMapKey(fields.ByName("number"), "12"); err != nil || key.Interface() != int32(12) {
+		t.Fatalf("unexpected key %v / error %v", key, err)
+	}
+
+	for _, key := range []string{"012", "+12", "12.0", "1.2e1"} {
+		if _, err := toProtoMapKey(fields.ByName("number"), key); err == nil || err.Error() != "invalid int32 '"+key+"'" {
+			t.Fatalf("expected an error for key %s, got %v", key, err)
+		}
+	}
+
+	if _, err := toProtoMapKey(fields.ByName("number"), "1.5"); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if key, err := toProtoMapKey(fields.ByName("packed"), "true"); err != nil || key.Interface() != true {
+		t.Fatalf("unexpected key %v / error %v", key, err)
+	}
+
+	if _, err := toProtoMapKey(fields.ByName("packed"), "1"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,246 @@
+// Package protobuf provides dot notation handlers for protocol buffers, supporting the structpb types, Struct,
+// ListValue, and Value, which are addressed like the JSON they represent, and the fields of any message, see Getter.
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/joeycumines/go-dotnotation/dotnotation"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Register registers Getter, Setter, and Deleter with registry, for all proto.Message and protoreflect.Message
+// values.
+func Register(registry *dotnotation.Registry) {
+	for _, t := range []reflect.Type{
+		reflect.TypeOf((*proto.Message)(nil)).Elem(),
+		reflect.TypeOf((*protoreflect.Message)(nil)).Elem(),
+	} {
+		registry.RegisterGetter(t, Getter)
+		registry.RegisterSetter(t, Setter)
+		registry.RegisterDeleter(t, Deleter)
+	}
+}
+
+// Getter returns the property value of a given target, or an error, supporting *structpb.Struct, *structpb.ListValue,
+// and *structpb.Value, where values are returned as nil, float64, string, bool, *structpb.Struct, or
+// *structpb.ListValue, and the fields of any other proto.Message or protoreflect.Message, by JSON or proto name, see
+// MessageGetter. Any other target is passed to dotnotation.DefaultGetter.
+func Getter(target interface{}, property string) (interface{}, error) {
+	switch v := target.(type) {
+	case *structpb.Struct:
+		value, ok := v.GetFields()[property]
+
+		if !ok {
+			return nil, fmt.Errorf("cannot get non-existent property '%s' on a struct", property)
+		}
+
+		return unwrapValue(value), nil
+
+	case *structpb.ListValue:
+		i, err := dotnotation.ParseIndex(property)
+
+		if err != nil {
+			return nil, fmt.Errorf("cannot get non-integer property '%s' on a list", property)
+		}
+
+		if i < 0 || i >= len(v.GetValues()) {
+			return nil, fmt.Errorf("cannot get out of range property '%s' on a list", property)
+		}
+
+		return unwrapValue(v.Values[i]), nil
+
+	case *structpb.Value:
+		if container := valueContainer(v); container != nil {
+			return Getter(container, property)
+		}
+
+		return nil, fmt.Errorf("cannot get property '%s' on a %s value", property, valueKind(v))
+
+	case proto.Message:
+		return MessageGetter(v.ProtoReflect(), property)
+
+	case protoreflect.Message:
+		return MessageGetter(v, property)
+
+	default:
+		return dotnotation.DefaultGetter(target, property)
+	}
+}
+
+// Setter sets the property value of a given target, to a given value, or returns an error, supporting the same
+// targets as Getter, where values set within the structpb types are converted using structpb.NewValue, and
+// appending to a *structpb.ListValue is supported, by setting the index equal to the length. Message fields are set
+// using MessageSetter. Any other target is passed to dotnotation.DefaultSetter.
+func Setter(target interface{}, property string, value interface{}) error {
+	switch v := target.(type) {
+	case *structpb.Struct:
+		element, err := newValue(value)
+		if err != nil {
+			return err
+		}
+
+		if v.Fields == nil {
+			v.Fields = make(map[string]*structpb.Value)
+		}
+
+		v.Fields[property] = element
+
+		return nil
+
+	case *structpb.ListValue:
+		i, err := dotnotation.ParseIndex(property)
+
+		if err != nil {
+			return fmt.Errorf("cannot set non-integer property '%s' on a list", property)
+		}
+
+		if i < 0 || i > len(v.Values) {
+			return fmt.Errorf("cannot set out of range property '%s' on a list", property)
+		}
+
+		element, err := newValue(value)
+		if err != nil {
+			return err
+		}
+
+		if i == len(v.Values) {
+			v.Values = append(v.Values, element)
+		} else {
+			v.Values[i] = element
+		}
+
+		return nil
+
+	case *structpb.Value:
+		if container := valueContainer(v); container != nil {
+			return Setter(container, property, value)
+		}
+
+		return fmt.Errorf("cannot set property '%s' on a %s value", property, valueKind(v))
+
+	case proto.Message:
+		return MessageSetter(v.ProtoReflect(), property, value)
+
+	case protoreflect.Message:
+		return MessageSetter(v, property, value)
+
+	default:
+		return dotnotation.DefaultSetter(target, property, value)
+	}
+}
+
+// Deleter removes the property from a given target, or returns an error, supporting the same targets as Getter,
+// where message fields are cleared. Any other target is passed to dotnotation.DefaultDeleter.
+func Deleter(target interface{}, property string) error {
+	switch v := target.(type) {
+	case *structpb.Struct:
+		if _, ok := v.GetFields()[property]; !ok {
+			return fmt.Errorf("cannot delete non-existent property '%s' on a struct", property)
+		}
+
+		delete(v.Fields, property)
+
+		return nil
+
+	case *structpb.ListValue:
+		i, err := dotnotation.ParseIndex(property)
+
+		if err != nil {
+			return fmt.Errorf("cannot delete non-integer property '%s' on a list", property)
+		}
+
+		if i < 0 || i >= len(v.GetValues()) {
+			return fmt.Errorf("cannot delete out of range property '%s' on a list", property)
+		}
+
+		v.Values = append(v.Values[:i], v.Values[i+1:]...)
+
+		return nil
+
+	case *structpb.Value:
+		if container := valueContainer(v); container != nil {
+			return Deleter(container, property)
+		}
+
+		return fmt.Errorf("cannot delete property '%s' on a %s value", property, valueKind(v))
+
+	case proto.Message:
+		return MessageDeleter(v.ProtoReflect(), property)
+
+	case protoreflect.Message:
+		return MessageDeleter(v, property)
+
+	default:
+		return dotnotation.DefaultDeleter(target, property)
+	}
+}
+
+// unwrapValue returns the Go value of v, where structs and lists are returned as-is, so they may be modified.
+func unwrapValue(v *structpb.Value) interface{} {
+	switch kind := v.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		return kind.NumberValue
+	case *structpb.Value_StringValue:
+		return kind.StringValue
+	case *structpb.Value_BoolValue:
+		return kind.BoolValue
+	case *structpb.Value_StructValue:
+		return kind.StructValue
+	case *structpb.Value_ListValue:
+		return kind.ListValue
+	default:
+		return nil
+	}
+}
+
+// valueContainer returns the struct or list within v, or nil.
+func valueContainer(v *structpb.Value) interface{} {
+	switch kind := v.GetKind().(type) {
+	case *structpb.Value_StructValue:
+		return kind.StructValue
+	case *structpb.Value_ListValue:
+		return kind.ListValue
+	default:
+		return nil
+	}
+}
+
+// valueKind describes the kind of v, for errors.
+func valueKind(v *structpb.Value) string {
+	switch v.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		return "number"
+	case *structpb.Value_StringValue:
+		return "string"
+	case *structpb.Value_BoolValue:
+		return "bool"
+	default:
+		return "null"
+	}
+}
+
+// newValue converts value to a *structpb.Value, using structpb.NewValue, with additional support for the structpb
+// types, and json.Number.
+func newValue(value interface{}) (*structpb.Value, error) {
+	switch v := value.(type) {
+	case *structpb.Value:
+		return v, nil
+	case *structpb.Struct:
+		return structpb.NewStructValue(v), nil
+	case *structpb.ListValue:
+		return structpb.NewListValue(v), nil
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return structpb.NewNumberValue(f), nil
+	default:
+		return structpb.NewValue(value)
+	}
+}
@@ -0,0 +1,170 @@
+package protobuf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/joeycumines/go-dotnotation/dotnotation"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestStruct(t *testing.T) {
+	s, err := structpb.NewStruct(map[string]interface{}{
+		"user": map[string]interface{}{
+			"name": "joe",
+			"tags": []interface{}{"a", "b"},
+		},
+		"count": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accessor := dotnotation.Accessor{Getter: Getter, Setter: Setter, Deleter: Deleter}
+
+	type TestCase struct {
+		Key   string
+		Value interface{}
+		Err   string
+	}
+
+	testCases := []TestCase{
+		{Key: "user.name", Value: "joe"},
+		{Key: "user.tags.1", Value: "b"},
+		{Key: "count", Value: float64(1)},
+		{Key: "missing", Err: "cannot get non-existent property 'missing' on a struct"},
+		{Key: "user.tags.2", Err: "cannot get out of range property '2' on a list"},
+		{Key: "user.tags.01", Err: "cannot get non-integer property '01' on a list"},
+		{Key: "user.name.one", Err: "cannot get property 'one' on type string"},
+	}
+
+	for i, testCase := range testCases {
+		value, err := accessor.Get(s, testCase.Key)
+
+		if (err == nil) != (testCase.Err == "") || (err != nil && err.Error() != testCase.Err) {
+			t.Errorf("test case %d expected error %q, got %v", i, testCase.Err, err)
+		}
+
+		if diff := deep.Equal(testCase.Value, value); diff != nil {
+			t.Errorf("test case %d unexpected value: %s", i, strings.Join(diff, ", "))
+		}
+	}
+
+	if err := accessor.Set(s, "user.tags.2", map[string]interface{}{"c": true}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Set(s, "user.tags.0", structpb.NewNullValue()); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Set(s, "empty", &structpb.Struct{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Set(s, "empty.one", []interface{}{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Delete(s, "user.tags.1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Delete(s, "count"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := accessor.Set(s, "user.tags.3", 1); err == nil || err.Error() != "cannot set out of range property '3' on a list" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := accessor.Set(s, "user.name.one", 1); err == nil || err.Error() != "cannot set property 'one' on type string" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := accessor.Set(s, "user.name", []string{}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if err := accessor.Delete(s, "missing"); err == nil || err.Error() != "cannot delete non-existent property 'missing' on a struct" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name": "joe",
+			"tags": []interface{}{nil, map[string]interface{}{"c": true}},
+		},
+		"empty": map[string]interface{}{"one": []interface{}{}},
+	}
+
+	if diff := deep.Equal(expected, s.AsMap()); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+}
+
+func TestValue(t *testing.T) {
+	list := structpb.NewListValue(&structpb.ListValue{})
+
+	if err := Setter(list, "0", "one"); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := Getter(list, "0"); err != nil || value != "one" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if err := Deleter(list, "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(list.GetListValue().GetValues()); n != 0 {
+		t.Fatalf("unexpected length %d", n)
+	}
+
+	number := structpb.NewNumberValue(1)
+
+	if _, err := Getter(number, "one"); err == nil || err.Error() != "cannot get property 'one' on a number value" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := Setter(number, "one", 1); err == nil || err.Error() != "cannot set property 'one' on a number value" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := Deleter(structpb.NewNullValue(), "one"); err == nil || err.Error() != "cannot delete property 'one' on a null value" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var registry dotnotation.Registry
+
+	Register(&registry)
+
+	s, err := structpb.NewStruct(map[string]interface{}{"one": "two"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := map[string]interface{}{"payload": s}
+
+	accessor := registry.Accessor()
+
+	if err := accessor.Set(target, "payload.three", "four"); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := accessor.Get(target, "payload.three"); err != nil || value != "four" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	if err := accessor.Delete(target, "payload.one"); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(map[string]interface{}{"three": "four"}, s.AsMap()); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+}
@@ -0,0 +1,36 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// decodeRaw returns value, or if it's a json.RawMessage, it's decoded value, after replacing value in it's parent.
+func (p Accessor) decodeRaw(parent interface{}, property string, value interface{}) (interface{}, error) {
+	raw, ok := value.(json.RawMessage)
+	if !ok {
+		return value, nil
+	}
+
+	decoded, err := decodeRawJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.setter(parent, property, decoded); err != nil {
+		return nil, err
+	}
+
+	return decoded, nil
+}
+
+// decodeRawJSON decodes raw as per encoding/json, into an interface{}.
+func decodeRawJSON(raw json.RawMessage) (interface{}, error) {
+	var value interface{}
+
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, fmt.Errorf("cannot decode json.RawMessage: %v", err)
+	}
+
+	return value, nil
+}
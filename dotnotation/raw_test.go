@@ -0,0 +1,163 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGet_rawJSON(t *testing.T) {
+	raw := json.RawMessage(`{"users": [{"name": "joe"}, {"name": "bob"}]}`)
+
+	target := map[string]interface{}{
+		"data":    raw,
+		"pointer": &raw,
+		"invalid": json.RawMessage(`{`),
+	}
+
+	type TestCase struct {
+		Key   string
+		Value interface{}
+		Err   string
+	}
+
+	testCases := []TestCase{
+		{Key: "data.users.1.name", Value: "bob"},
+		{Key: "pointer.users.0.name", Value: "joe"},
+		{Key: "data.users.2", Err: "cannot get out of range property '2' on a slice"},
+		{Key: "invalid.one", Err: "cannot decode json.RawMessage: unexpected end of JSON input"},
+	}
+
+	for i, testCase := range testCases {
+		value, err := Get(target, testCase.Key)
+
+		if (err == nil) != (testCase.Err == "") || (err != nil && err.Error() != testCase.Err) {
+			t.Errorf("test case %d expected error %q, got %v", i, testCase.Err, err)
+		}
+
+		if diff := deep.Equal(testCase.Value, value); diff != nil {
+			t.Errorf("test case %d unexpected value: %s", i, strings.Join(diff, ", "))
+		}
+	}
+
+	if _, ok := target["data"].(json.RawMessage); !ok {
+		t.Fatalf("unexpected value %T", target["data"])
+	}
+}
+
+func TestAccessor_CacheRawJSON(t *testing.T) {
+	target := map[string]interface{}{
+		"data": json.RawMessage(`{"user": {"name": "joe"}, "tags": ["a"]}`),
+	}
+
+	accessor := Accessor{CacheRawJSON: true}
+
+	if value, err := accessor.Get(target, "data.user.name"); err != nil || value != "joe" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{
+			"user": map[string]interface{}{"name": "joe"},
+			"tags": []interface{}{"a"},
+		},
+	}
+
+	if diff := deep.Equal(expected, target); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+}
+
+func TestSet_rawJSON(t *testing.T) {
+	target := map[string]interface{}{
+		"data": json.RawMessage(`{"user": {"name": "joe"}, "tags": ["a"]}`),
+	}
+
+	if err := Set(target, "data.user.name", "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Default().Append(target, "data.tags", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Default().Delete(target, "data.user.name"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"data": map[string]interface{}{
+			"user": map[string]interface{}{},
+			"tags": []interface{}{"a", "b"},
+		},
+	}
+
+	if diff := deep.Equal(expected, target); diff != nil {
+		t.Fatal(strings.Join(diff, ", "))
+	}
+}
+
+func TestAccessor_CacheRawJSON_concurrent(t *testing.T) {
+	newRoot := func() map[string]interface{} {
+		root := make(map[string]interface{})
+		for i := 0; i < 8; i++ {
+			root[strconv.Itoa(i)] = json.RawMessage(`{"b": "value"}`)
+		}
+		return root
+	}
+
+	accessor := Accessor{CacheRawJSON: true}
+
+	document := NewDocument(accessor, newRoot())
+
+	snapshotRoot := newRoot()
+	snapshot := NewSnapshot(accessor, snapshotRoot)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			key := strconv.Itoa(j) + ".b"
+
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+				if value, err := document.Get(key); err != nil || value != "value" {
+					t.Errorf("unexpected value %v / error %v", value, err)
+				}
+			}()
+
+			go func() {
+				defer wg.Done()
+				if value, err := snapshot.Get(key); err != nil || value != "value" {
+					t.Errorf("unexpected value %v / error %v", value, err)
+				}
+			}()
+		}
+	}
+
+	wg.Wait()
+
+	for key, value := range snapshotRoot {
+		if _, ok := value.(json.RawMessage); !ok {
+			t.Errorf("unexpected value %T for %s", value, key)
+		}
+	}
+
+	if _, err := snapshot.Apply(Operation{Key: "0.b", Value: "changed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := snapshotRoot["0"].(json.RawMessage); !ok {
+		t.Fatalf("unexpected value %T", snapshotRoot["0"])
+	}
+
+	if value, err := snapshot.Get("0.b"); err != nil || value != "changed" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+}
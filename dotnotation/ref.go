@@ -0,0 +1,139 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// RefProperty is the property identifying a JSON reference, see Accessor.FollowRefs.
+const RefProperty = "$ref"
+
+// getRefs implements Get, for a non-empty list of properties, following any JSON references, including the target,
+// and the result.
+func (p Accessor) getRefs(target interface{}, properties []string) (interface{}, error) {
+	// the document containing the current value, and it's uri
+	root, uri := target, ""
+
+	target, root, uri, err := p.followRef(target, root, uri, make(map[string]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, property := range properties {
+		if target, err = p.getter(target, property); err != nil {
+			return nil, err
+		}
+
+		if target, root, uri, err = p.followRef(target, root, uri, make(map[string]bool)); err != nil {
+			return nil, err
+		}
+	}
+
+	return target, nil
+}
+
+// followRef returns value, or if it's a reference, the referenced value, following chains of references, and any
+// references within each pointer, along with the document containing the result, and it's uri, where root is the
+// document containing value. References in the process of being followed are tracked, to detect cycles.
+func (p Accessor) followRef(value interface{}, root interface{}, uri string, following map[string]bool) (interface{}, interface{}, string, error) {
+	var followed []string
+
+	defer func() {
+		for _, id := range followed {
+			delete(following, id)
+		}
+	}()
+
+	for {
+		ref, ok := p.ref(value)
+		if !ok {
+			return value, root, uri, nil
+		}
+
+		refURI, fragment := ref, ""
+		if i := strings.Index(ref, "#"); i >= 0 {
+			refURI, fragment = ref[:i], ref[i+1:]
+		}
+
+		if refURI != "" {
+			if p.ResolveRef == nil {
+				return nil, nil, "", fmt.Errorf("cannot resolve reference '%s' without ResolveRef", ref)
+			}
+
+			document, err := p.ResolveRef(refURI)
+			if err != nil {
+				return nil, nil, "", err
+			}
+
+			root, uri = document, refURI
+		}
+
+		id := uri + "#" + fragment
+		if following[id] {
+			return nil, nil, "", fmt.Errorf("circular reference '%s'", ref)
+		}
+		following[id] = true
+		followed = append(followed, id)
+
+		tokens, err := parsePointer(fragment)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("invalid reference '%s': %v", ref, err)
+		}
+
+		value = root
+
+		for _, token := range tokens {
+			if value, root, uri, err = p.followRef(value, root, uri, following); err != nil {
+				return nil, nil, "", err
+			}
+
+			if value, err = p.getter(value, token); err != nil {
+				return nil, nil, "", fmt.Errorf("cannot resolve reference '%s': %v", ref, err)
+			}
+		}
+	}
+}
+
+// ref returns the reference of value, if it has a string RefProperty.
+func (p Accessor) ref(value interface{}) (string, bool) {
+	switch value.(type) {
+	case nil, string, bool, float64, int, json.Number:
+		return "", false
+	}
+
+	v, err := p.getter(value, RefProperty)
+	if err != nil {
+		return "", false
+	}
+
+	ref, ok := v.(string)
+
+	return ref, ok
+}
+
+// parsePointer parses a JSON pointer, from a URI fragment, per RFC 6901.
+func parsePointer(fragment string) ([]string, error) {
+	pointer, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, err
+	}
+
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("pointer must start with '/'")
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,126 @@
+package dotnotation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAccessor_Get_followRefs(t *testing.T) {
+	common := map[string]interface{}{
+		"definitions": map[string]interface{}{
+			"Error": map[string]interface{}{"type": "object", "title": "error"},
+			"Alias": map[string]interface{}{"$ref": "#/definitions/Error"},
+		},
+	}
+
+	document := map[string]interface{}{
+		"paths": map[string]interface{}{
+			"/pets": map[string]interface{}{
+				"get": map[string]interface{}{
+					"responses": map[string]interface{}{
+						"200":     map[string]interface{}{"$ref": "#/definitions/Pets"},
+						"default": map[string]interface{}{"$ref": "common.json#/definitions/Alias"},
+						"missing": map[string]interface{}{"$ref": "#/definitions/Missing"},
+						"unknown": map[string]interface{}{"$ref": "unknown.json#/definitions/Error"},
+					},
+				},
+			},
+		},
+		"definitions": map[string]interface{}{
+			"Pet": map[string]interface{}{"type": "object", "title": "pet"},
+			"Pets": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"$ref": "#/definitions/Pet"},
+			},
+			"a~b/c":    map[string]interface{}{"title": "escaped"},
+			"Escaped":  map[string]interface{}{"$ref": "#/definitions/a~0b~1c"},
+			"Cycle":    map[string]interface{}{"$ref": "#/definitions/Cycle2"},
+			"Cycle2":   map[string]interface{}{"$ref": "#/definitions/Cycle"},
+			"Self":     map[string]interface{}{"$ref": "#/definitions/Self/title"},
+			"Node":     map[string]interface{}{"title": "node", "child": map[string]interface{}{"$ref": "#/definitions/Node"}},
+			"External": map[string]interface{}{"$ref": "common.json"},
+		},
+	}
+
+	accessor := Accessor{
+		FollowRefs: true,
+		ResolveRef: func(uri string) (interface{}, error) {
+			if uri == "common.json" {
+				return common, nil
+			}
+			return nil, errors.New("unknown document " + uri)
+		},
+	}
+
+	testCases := []struct {
+		key    string
+		result interface{}
+		err    string
+	}{
+		{key: "paths./pets.get.responses.200.items.title", result: "pet"},
+		{key: "paths./pets.get.responses.200.type", result: "array"},
+		{key: "paths./pets.get.responses.default.title", result: "error"},
+		{key: "paths./pets.get.responses.missing.title", err: "cannot resolve reference '#/definitions/Missing': cannot get non-existent property 'Missing' on a map"},
+		{key: "paths./pets.get.responses.unknown", err: "unknown document unknown.json"},
+		{key: "definitions.Escaped.title", result: "escaped"},
+		{key: "definitions.Cycle", err: "circular reference '#/definitions/Cycle2'"},
+		{key: "definitions.Self", err: "circular reference '#/definitions/Self/title'"},
+		{key: "definitions.Node.child.child.child.title", result: "node"},
+		{key: "definitions.External.definitions.Alias.type", result: "object"},
+	}
+
+	for _, testCase := range testCases {
+		result, err := accessor.Get(document, testCase.key)
+
+		if testCase.err != "" {
+			if err == nil || err.Error() != testCase.err {
+				t.Errorf("%s failed: unexpected error %v", testCase.key, err)
+			}
+			continue
+		}
+
+		if err != nil || result != testCase.result {
+			t.Errorf("%s failed: unexpected result %v / error %v", testCase.key, result, err)
+		}
+	}
+
+	// references are not followed by default
+	if result, err := Get(document, "paths./pets.get.responses.200.$ref"); err != nil || result != "#/definitions/Pets" {
+		t.Errorf("unexpected result %v / error %v", result, err)
+	}
+
+	// references to other documents require ResolveRef
+	accessor.ResolveRef = nil
+
+	if _, err := accessor.Get(document, "paths./pets.get.responses.default.title"); err == nil || !strings.Contains(err.Error(), "without ResolveRef") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestParsePointer(t *testing.T) {
+	testCases := []struct {
+		fragment string
+		tokens   []string
+		success  bool
+	}{
+		{fragment: "", tokens: nil, success: true},
+		{fragment: "/", tokens: []string{""}, success: true},
+		{fragment: "/a~1b/c~0d/%7Be%7D", tokens: []string{"a/b", "c~d", "{e}"}, success: true},
+		{fragment: "a", success: false},
+		{fragment: "/%zz", success: false},
+	}
+
+	for _, testCase := range testCases {
+		tokens, err := parsePointer(testCase.fragment)
+
+		if (err == nil) != testCase.success {
+			t.Errorf("%s failed: unexpected error %v", testCase.fragment, err)
+			continue
+		}
+
+		if strings.Join(tokens, ",") != strings.Join(testCase.tokens, ",") || len(tokens) != len(testCase.tokens) {
+			t.Errorf("%s failed: unexpected tokens %v", testCase.fragment, tokens)
+		}
+	}
+}
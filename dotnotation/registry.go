@@ -0,0 +1,124 @@
+package dotnotation
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Registry dispatches to getters, setters, and deleters registered for the type of each target, falling back to
+// DefaultGetter, DefaultSetter, and DefaultDeleter. Handlers may be registered for concrete types, which are matched
+// exactly, or interface types, which are checked in the order they were registered, if there was no exact match.
+// The zero value is ready to use, and it's safe to register handlers concurrently with their use.
+type Registry struct {
+	mutex    sync.RWMutex
+	handlers map[reflect.Type]*registryHandlers
+	// interfaces lists the registered interface types, in order
+	interfaces []reflect.Type
+}
+
+type registryHandlers struct {
+	getter  GetterFunc
+	setter  SetterFunc
+	deleter DeleterFunc
+}
+
+// Accessor returns an Accessor using the Getter, Setter, and Deleter methods of the registry.
+func (r *Registry) Accessor() Accessor {
+	return Accessor{
+		Getter:  r.Getter,
+		Setter:  r.Setter,
+		Deleter: r.Deleter,
+	}
+}
+
+// RegisterGetter registers a getter for targets of type t, replacing any existing getter for t.
+func (r *Registry) RegisterGetter(t reflect.Type, getter GetterFunc) {
+	r.register(t, func(h *registryHandlers) {
+		h.getter = getter
+	})
+}
+
+// RegisterSetter registers a setter for targets of type t, replacing any existing setter for t.
+func (r *Registry) RegisterSetter(t reflect.Type, setter SetterFunc) {
+	r.register(t, func(h *registryHandlers) {
+		h.setter = setter
+	})
+}
+
+// RegisterDeleter registers a deleter for targets of type t, replacing any existing deleter for t.
+func (r *Registry) RegisterDeleter(t reflect.Type, deleter DeleterFunc) {
+	r.register(t, func(h *registryHandlers) {
+		h.deleter = deleter
+	})
+}
+
+// Getter calls the getter registered for the type of target, or DefaultGetter.
+func (r *Registry) Getter(target interface{}, property string) (interface{}, error) {
+	if getter := r.lookup(target, func(h *registryHandlers) bool { return h.getter != nil }).getter; getter != nil {
+		return getter(target, property)
+	}
+
+	return DefaultGetter(target, property)
+}
+
+// Setter calls the setter registered for the type of target, or DefaultSetter.
+func (r *Registry) Setter(target interface{}, property string, value interface{}) error {
+	if setter := r.lookup(target, func(h *registryHandlers) bool { return h.setter != nil }).setter; setter != nil {
+		return setter(target, property, value)
+	}
+
+	return DefaultSetter(target, property, value)
+}
+
+// Deleter calls the deleter registered for the type of target, or DefaultDeleter.
+func (r *Registry) Deleter(target interface{}, property string) error {
+	if deleter := r.lookup(target, func(h *registryHandlers) bool { return h.deleter != nil }).deleter; deleter != nil {
+		return deleter(target, property)
+	}
+
+	return DefaultDeleter(target, property)
+}
+
+func (r *Registry) register(t reflect.Type, fn func(h *registryHandlers)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.handlers == nil {
+		r.handlers = make(map[reflect.Type]*registryHandlers)
+	}
+
+	// copy on write, as lookup returns handlers after releasing the lock
+	var updated registryHandlers
+
+	if h, ok := r.handlers[t]; ok {
+		updated = *h
+	} else if t.Kind() == reflect.Interface {
+		r.interfaces = append(r.interfaces, t)
+	}
+
+	fn(&updated)
+	r.handlers[t] = &updated
+}
+
+// lookup returns the handlers for the type of target that match ok, or empty handlers.
+func (r *Registry) lookup(target interface{}, ok func(h *registryHandlers) bool) *registryHandlers {
+	t := reflect.TypeOf(target)
+	if t == nil {
+		return &registryHandlers{}
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if h, exists := r.handlers[t]; exists && ok(h) {
+		return h
+	}
+
+	for _, i := range r.interfaces {
+		if h := r.handlers[i]; t.Implements(i) && ok(h) {
+			return h
+		}
+	}
+
+	return &registryHandlers{}
+}
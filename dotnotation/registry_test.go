@@ -0,0 +1,103 @@
+package dotnotation
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+type registryList struct {
+	values []interface{}
+}
+
+type registryNamed interface {
+	Name() string
+}
+
+type registryPerson struct {
+	name string
+}
+
+func (p registryPerson) Name() string {
+	return p.name
+}
+
+func TestRegistry(t *testing.T) {
+	var registry Registry
+
+	registry.RegisterGetter(reflect.TypeOf(&registryList{}), func(target interface{}, property string) (interface{}, error) {
+		i, err := strconv.Atoi(property)
+		if err != nil {
+			return nil, err
+		}
+		return target.(*registryList).values[i], nil
+	})
+	registry.RegisterSetter(reflect.TypeOf(&registryList{}), func(target interface{}, property string, value interface{}) error {
+		i, err := strconv.Atoi(property)
+		if err != nil {
+			return err
+		}
+		target.(*registryList).values[i] = value
+		return nil
+	})
+	registry.RegisterDeleter(reflect.TypeOf(&registryList{}), func(target interface{}, property string) error {
+		return errors.New("cannot delete from a list")
+	})
+	registry.RegisterGetter(reflect.TypeOf((*registryNamed)(nil)).Elem(), func(target interface{}, property string) (interface{}, error) {
+		if property != "name" {
+			return nil, errors.New("unknown property " + property)
+		}
+		return target.(registryNamed).Name(), nil
+	})
+
+	accessor := registry.Accessor()
+
+	target := map[string]interface{}{
+		"list": &registryList{values: []interface{}{
+			registryPerson{name: "joe"},
+			map[string]interface{}{"one": 1},
+		}},
+	}
+
+	// interface handler
+	if value, err := accessor.Get(target, "list.0.name"); err != nil || value != "joe" {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+
+	// default handling, within a custom type
+	if err := accessor.Set(target, "list.1.two", 2); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := accessor.Set(target, "list.0", 3); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if err := accessor.Delete(target, "list.0"); err == nil || err.Error() != "cannot delete from a list" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// no setter for the interface, so falls back to the default
+	if err := accessor.Set(registryPerson{}, "name", 1); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	expected := map[string]interface{}{
+		"list": &registryList{values: []interface{}{
+			3,
+			map[string]interface{}{"one": 1, "two": 2},
+		}},
+	}
+
+	if diff := deep.Equal(expected["list"].(*registryList).values, target["list"].(*registryList).values); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	if _, err := registry.Getter(nil, "one"); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,155 @@
+package dotnotation
+
+import (
+	"errors"
+	"sort"
+)
+
+// Select returns a new document containing deep copies of only the values at keys, within target, keys that cannot
+// be got are ignored. The nesting of values is preserved, creating map[string]interface{} for any container other
+// than a slice, and slice positions are preserved, by padding with nil values, e.g. selecting "items.1" results in
+// []interface{}{nil, value} for "items". If no keys can be got, the result is an empty []interface{}, if target is a
+// slice, or an empty map[string]interface{}.
+func (p Accessor) Select(target interface{}, keys ...string) (interface{}, error) {
+	var result interface{}
+
+	for _, key := range keys {
+		properties := p.parser(key)
+
+		if len(properties) == 0 {
+			return nil, errors.New("no properties parsed from key: " + key)
+		}
+
+		values, err := p.walk(target, properties, false)
+		if err != nil {
+			continue
+		}
+
+		result = selectValue(result, values, properties)
+	}
+
+	if result == nil {
+		switch target.(type) {
+		case []interface{}, *[]interface{}:
+			result = []interface{}{}
+		default:
+			result = map[string]interface{}{}
+		}
+	}
+
+	return result, nil
+}
+
+// Omit returns a deep copy of target, as per Copy, with the values at keys deleted, keys that cannot be got are
+// ignored. Keys are deleted in descending order, so that indexes always refer to positions in the original slices,
+// and duplicate keys are only deleted once.
+func (p Accessor) Omit(target interface{}, keys ...string) (interface{}, error) {
+	paths := make([][]string, 0, len(keys))
+
+	for _, key := range keys {
+		properties := p.parser(key)
+
+		if len(properties) == 0 {
+			return nil, errors.New("no properties parsed from key: " + key)
+		}
+
+		paths = append(paths, properties)
+	}
+
+	sort.Slice(paths, func(i, j int) bool {
+		return compareProperties(paths[i], paths[j]) > 0
+	})
+
+	// duplicates are adjacent, after sorting
+	unique := paths[:0]
+	for _, properties := range paths {
+		if len(unique) == 0 || compareProperties(unique[len(unique)-1], properties) != 0 {
+			unique = append(unique, properties)
+		}
+	}
+	paths = unique
+
+	result := deepCopy(target)
+
+	// a pointer is required to delete from a slice without a parent
+	root := result
+	slice, isSlice := result.([]interface{})
+	if isSlice {
+		root = &slice
+	}
+
+	for _, properties := range paths {
+		if _, err := p.get(root, properties); err != nil {
+			continue
+		}
+
+		if err := p.delete(root, properties); err != nil {
+			return nil, err
+		}
+	}
+
+	if isSlice {
+		return slice, nil
+	}
+
+	return result, nil
+}
+
+// selectValue returns dst, updated to contain a copy of the last of values, where values are as returned by walk,
+// for properties.
+func selectValue(dst interface{}, values []interface{}, properties []string) interface{} {
+	if len(properties) == 0 {
+		return deepCopy(values[0])
+	}
+
+	switch values[0].(type) {
+	case []interface{}, *[]interface{}:
+		i, err := ParseIndex(properties[0])
+		if err != nil || i < 0 {
+			break
+		}
+
+		slice, _ := dst.([]interface{})
+		if i >= len(slice) {
+			slice = append(slice, make([]interface{}, i+1-len(slice))...)
+		}
+
+		slice[i] = selectValue(slice[i], values[1:], properties[1:])
+
+		return slice
+	}
+
+	m, ok := dst.(map[string]interface{})
+	if !ok {
+		m = make(map[string]interface{})
+	}
+
+	m[properties[0]] = selectValue(m[properties[0]], values[1:], properties[1:])
+
+	return m
+}
+
+// compareProperties orders lists of properties, comparing integer properties numerically, and returns a negative
+// number if a is before b, a positive number if a is after b, or zero if they are equal.
+func compareProperties(a []string, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+
+		x, errX := ParseIndex(a[i])
+		y, errY := ParseIndex(b[i])
+
+		if errX == nil && errY == nil {
+			return x - y
+		}
+
+		if a[i] < b[i] {
+			return -1
+		}
+
+		return 1
+	}
+
+	return len(a) - len(b)
+}
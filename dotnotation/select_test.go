@@ -0,0 +1,174 @@
+package dotnotation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestAccessor_Select(t *testing.T) {
+	target := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": 1,
+			"c": 2,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"name": "one", "id": 1},
+			map[string]interface{}{"name": "two", "id": 2},
+			map[string]interface{}{"name": "three", "id": 3},
+		},
+		"d": 3,
+	}
+
+	testCases := []struct {
+		name    string
+		keys    []string
+		outcome interface{}
+	}{
+		{
+			name:    "none",
+			keys:    nil,
+			outcome: map[string]interface{}{},
+		},
+		{
+			name:    "no matches",
+			keys:    []string{"e", "a.e"},
+			outcome: map[string]interface{}{},
+		},
+		{
+			name: "nested",
+			keys: []string{"a.b", "d"},
+			outcome: map[string]interface{}{
+				"a": map[string]interface{}{"b": 1},
+				"d": 3,
+			},
+		},
+		{
+			name: "slice positions",
+			keys: []string{"items.1.name", "items.2.id"},
+			outcome: map[string]interface{}{
+				"items": []interface{}{
+					nil,
+					map[string]interface{}{"name": "two"},
+					map[string]interface{}{"id": 3},
+				},
+			},
+		},
+		{
+			name: "overlapping",
+			keys: []string{"a.b", "a"},
+			outcome: map[string]interface{}{
+				"a": map[string]interface{}{"b": 1, "c": 2},
+			},
+		},
+		{
+			name: "missing",
+			keys: []string{"a.e", "f", "d"},
+			outcome: map[string]interface{}{
+				"d": 3,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, err := Accessor{}.Select(target, testCase.keys...)
+
+		if err != nil {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, result); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+
+	// the result must not share containers with the target
+	result, _ := Accessor{}.Select(target, "a")
+	result.(map[string]interface{})["a"].(map[string]interface{})["b"] = 4
+
+	if target["a"].(map[string]interface{})["b"] != 1 {
+		t.Error("expected the target to be unchanged")
+	}
+}
+
+func TestAccessor_Omit(t *testing.T) {
+	testCases := []struct {
+		name    string
+		target  interface{}
+		keys    []string
+		outcome interface{}
+	}{
+		{
+			name: "nested",
+			target: map[string]interface{}{
+				"a": map[string]interface{}{"b": 1, "c": 2},
+				"d": 3,
+			},
+			keys: []string{"a.b", "d", "e"},
+			outcome: map[string]interface{}{
+				"a": map[string]interface{}{"c": 2},
+			},
+		},
+		{
+			name: "slice positions",
+			target: map[string]interface{}{
+				"items": []interface{}{0, 1, 2, 3, []interface{}{4, 5}},
+			},
+			keys: []string{"items.1", "items.4.0", "items.2"},
+			outcome: map[string]interface{}{
+				"items": []interface{}{0, 3, []interface{}{5}},
+			},
+		},
+		{
+			name:    "root slice",
+			target:  []interface{}{0, 1, 2},
+			keys:    []string{"0", "2"},
+			outcome: []interface{}{1},
+		},
+		{
+			name: "duplicates",
+			target: map[string]interface{}{
+				"a": []interface{}{"x", "y", "z"},
+			},
+			keys: []string{"a.1", "a.1"},
+			outcome: map[string]interface{}{
+				"a": []interface{}{"x", "z"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		original := deepCopy(testCase.target)
+
+		result, err := Accessor{}.Omit(testCase.target, testCase.keys...)
+
+		if err != nil {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, result); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+
+		if diff := deep.Equal(original, testCase.target); diff != nil {
+			t.Errorf("%s failed: target modified %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Select_emptySlice(t *testing.T) {
+	target := []interface{}{1, 2}
+
+	for _, root := range []interface{}{target, &target} {
+		result, err := Accessor{}.Select(root, "2")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := deep.Equal([]interface{}{}, result); diff != nil {
+			t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package dotnotation
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Operation is a single change, applied by Snapshot.Apply.
+type Operation struct {
+	// Key is the key to set or delete.
+	Key string
+	// Value is the value to set, it's ignored if Delete is true.
+	Value interface{}
+	// Delete removes the value at Key, instead of setting it.
+	Delete bool
+}
+
+// Snapshot stores an immutable document, allowing lock-free reads, where changes are applied as a batch, by copying
+// each container along the path of each key, then publishing the new document, with an incremented version.
+// Copy-on-write is only supported for the types handled by DefaultGetter, other values are shared between versions,
+// and must not be modified.
+type Snapshot struct {
+	mutex    sync.Mutex
+	accessor Accessor
+	state    atomic.Value
+}
+
+type snapshotState struct {
+	root    interface{}
+	version uint64
+}
+
+// NewSnapshot returns a Snapshot with root as version 0, using accessor for all operations, except that CacheRawJSON is
+// disabled, as published versions must not be modified. The root value, and any values it contains, must not be
+// modified after this call.
+func NewSnapshot(accessor Accessor, root interface{}) *Snapshot {
+	accessor.CacheRawJSON = false
+	s := &Snapshot{accessor: accessor}
+	s.state.Store(&snapshotState{root: root})
+	return s
+}
+
+// Load returns the current root value, and it's version, neither of which will change, and the root value must not
+// be modified.
+func (s *Snapshot) Load() (root interface{}, version uint64) {
+	state := s.state.Load().(*snapshotState)
+	return state.root, state.version
+}
+
+// Version returns the current version, which is incremented by each successful call to Apply.
+func (s *Snapshot) Version() uint64 {
+	_, version := s.Load()
+	return version
+}
+
+// Get returns the value at key, within the current version, without locking. The result must not be modified.
+func (s *Snapshot) Get(key string) (interface{}, error) {
+	root, _ := s.Load()
+	return s.accessor.Get(root, key)
+}
+
+// Apply applies the operations in order, to a copy of the current version, publishing the result as the next version,
+// which is returned. If any operation fails, no changes are published. Calls to Apply are serialised.
+func (s *Snapshot) Apply(operations ...Operation) (uint64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	root, version := s.Load()
+
+	// containers that have already been copied, during this batch, by address
+	copied := make(map[uintptr]bool)
+
+	root = shallowCopy(root, copied)
+
+	for _, operation := range operations {
+		properties := s.accessor.parser(operation.Key)
+
+		if len(properties) == 0 {
+			return version, errors.New("no properties parsed from key: " + operation.Key)
+		}
+
+		s.copyPath(root, properties[:len(properties)-1], copied)
+
+		var err error
+		if operation.Delete {
+			err = s.accessor.delete(root, properties)
+		} else {
+			err = s.accessor.set(root, properties, operation.Value)
+		}
+
+		if err != nil {
+			return version, err
+		}
+	}
+
+	version++
+
+	s.state.Store(&snapshotState{root: root, version: version})
+
+	return version, nil
+}
+
+// copyPath replaces each container along properties with a shallow copy, unless it was already copied, stopping at
+// the first property that cannot be got.
+func (s *Snapshot) copyPath(target interface{}, properties []string, copied map[uintptr]bool) {
+	for _, property := range properties {
+		value, err := s.accessor.getter(target, property)
+		if err != nil {
+			return
+		}
+
+		if c := shallowCopy(value, copied); !sameContainer(c, value) {
+			if err := s.accessor.setter(target, property, c); err != nil {
+				return
+			}
+
+			value = c
+		}
+
+		target = value
+	}
+}
+
+// shallowCopy returns a copy of value, if it's one of the types supported by DefaultGetter, and it's address is not
+// in copied, in which case the address of the copy is added to copied.
+func shallowCopy(value interface{}, copied map[uintptr]bool) interface{} {
+	address, ok := containerAddress(value)
+	if !ok || copied[address] {
+		return value
+	}
+
+	var result interface{}
+
+	switch v := value.(type) {
+	case []interface{}:
+		result = append(make([]interface{}, 0, len(v)), v...)
+
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, element := range v {
+			m[key] = element
+		}
+		result = m
+
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for key, element := range v {
+			m[key] = element
+		}
+		result = m
+
+	case []string:
+		result = append(make([]string, 0, len(v)), v...)
+
+	case map[string][]string:
+		result = copyStringsMap(v)
+
+	case url.Values:
+		result = url.Values(copyStringsMap(v))
+
+	case http.Header:
+		result = http.Header(copyStringsMap(v))
+
+	case *[]interface{}:
+		slice := shallowCopy(*v, copied).([]interface{})
+		result = &slice
+
+	case *map[string]interface{}:
+		m := shallowCopy(*v, copied).(map[string]interface{})
+		result = &m
+
+	case *map[interface{}]interface{}:
+		m := shallowCopy(*v, copied).(map[interface{}]interface{})
+		result = &m
+
+	case *map[string][]string:
+		m := copyStringsMap(*v)
+		result = &m
+
+	case *url.Values:
+		m := url.Values(copyStringsMap(*v))
+		result = &m
+
+	case *http.Header:
+		m := http.Header(copyStringsMap(*v))
+		result = &m
+
+	case *multipart.Form:
+		form := &multipart.Form{Value: copyStringsMap(v.Value)}
+		if v.File != nil {
+			form.File = make(map[string][]*multipart.FileHeader, len(v.File))
+			for key, files := range v.File {
+				form.File[key] = files
+			}
+		}
+		result = form
+
+	case *Object:
+		object := &Object{keys: v.Keys(), values: make(map[string]interface{}, len(v.values))}
+		for key, element := range v.values {
+			object.values[key] = element
+		}
+		result = object
+	}
+
+	if address, ok := containerAddress(result); ok {
+		copied[address] = true
+	}
+
+	return result
+}
+
+// containerAddress returns the address of a non-nil value supported by shallowCopy.
+func containerAddress(value interface{}) (uintptr, bool) {
+	switch value.(type) {
+	case []interface{}, map[string]interface{}, map[interface{}]interface{}, *[]interface{}, *map[string]interface{},
+		*map[interface{}]interface{}, *Object, []string, map[string][]string, url.Values, http.Header,
+		*map[string][]string, *url.Values, *http.Header, *multipart.Form:
+		v := reflect.ValueOf(value)
+		if v.IsNil() {
+			return 0, false
+		}
+		return v.Pointer(), true
+
+	default:
+		return 0, false
+	}
+}
+
+// sameContainer returns true if a and b are the same container, as per containerAddress.
+func sameContainer(a interface{}, b interface{}) bool {
+	x, okX := containerAddress(a)
+	y, okY := containerAddress(b)
+	return okX == okY && x == y
+}
@@ -0,0 +1,173 @@
+package dotnotation
+
+import (
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestSnapshot_Apply(t *testing.T) {
+	original := map[string]interface{}{
+		"db": map[string]interface{}{
+			"hosts": []interface{}{"one"},
+			"port":  5432,
+		},
+		"cache": map[string]interface{}{
+			"ttl": 60,
+		},
+	}
+
+	expectedOriginal := deepCopy(original)
+
+	snapshot := NewSnapshot(Accessor{}, original)
+
+	if version := snapshot.Version(); version != 0 {
+		t.Fatalf("unexpected version %d", version)
+	}
+
+	version, err := snapshot.Apply(
+		Operation{Key: "db.hosts.1", Value: "two"},
+		Operation{Key: "db.hosts.0", Delete: true},
+		Operation{Key: "db.port", Value: 5433},
+		Operation{Key: "db.user", Value: "admin"},
+	)
+
+	if err != nil || version != 1 {
+		t.Fatalf("unexpected version %d / error %v", version, err)
+	}
+
+	root, version := snapshot.Load()
+
+	expected := map[string]interface{}{
+		"db": map[string]interface{}{
+			"hosts": []interface{}{"two"},
+			"port":  5433,
+			"user":  "admin",
+		},
+		"cache": map[string]interface{}{
+			"ttl": 60,
+		},
+	}
+
+	if diff := deep.Equal(expected, root); diff != nil || version != 1 {
+		t.Fatalf("unexpected version %d / diff %v", version, strings.Join(diff, ", "))
+	}
+
+	// the previous version must be unchanged
+	if diff := deep.Equal(expectedOriginal, original); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	// untouched containers are shared
+	if !sameContainer(original["cache"], root.(map[string]interface{})["cache"]) {
+		t.Fatal("expected cache to be shared")
+	}
+
+	// a failure publishes nothing
+	version, err = snapshot.Apply(
+		Operation{Key: "db.port", Value: 1},
+		Operation{Key: "missing.key", Value: 1},
+	)
+
+	if err == nil || version != 1 {
+		t.Fatalf("unexpected version %d / error %v", version, err)
+	}
+
+	if value, err := snapshot.Get("db.port"); err != nil || value != 5433 {
+		t.Fatalf("unexpected value %v / error %v", value, err)
+	}
+}
+
+func TestSnapshot_Apply_forms(t *testing.T) {
+	form := url.Values{"a": {"1"}}
+	header := http.Header{"K": {"one", "two"}}
+	multipartForm := &multipart.Form{Value: map[string][]string{"c": {"3"}}}
+
+	snapshot := NewSnapshot(Accessor{}, map[string]interface{}{
+		"form":      form,
+		"h":         header,
+		"multipart": multipartForm,
+	})
+
+	previous, _ := snapshot.Load()
+
+	if _, err := snapshot.Apply(
+		Operation{Key: "form.a", Value: "2"},
+		Operation{Key: "form.b", Value: "3"},
+		Operation{Key: "h.k.0", Value: "three"},
+		Operation{Key: "multipart.c.0", Value: "4"},
+		Operation{Key: "multipart.d", Value: "5"},
+	); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expectedPrevious := map[string]interface{}{
+		"form":      url.Values{"a": {"1"}},
+		"h":         http.Header{"K": {"one", "two"}},
+		"multipart": &multipart.Form{Value: map[string][]string{"c": {"3"}}},
+	}
+
+	if diff := deep.Equal(expectedPrevious, previous); diff != nil {
+		t.Errorf("expected the previous version to be unchanged: %v", strings.Join(diff, ", "))
+	}
+
+	root, _ := snapshot.Load()
+
+	expected := map[string]interface{}{
+		"form":      url.Values{"a": {"2"}, "b": {"3"}},
+		"h":         http.Header{"K": {"three", "two"}},
+		"multipart": &multipart.Form{Value: map[string][]string{"c": {"4"}, "d": {"5"}}},
+	}
+
+	if diff := deep.Equal(expected, root); diff != nil {
+		t.Errorf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}
+
+func TestSnapshot_concurrent(t *testing.T) {
+	snapshot := NewSnapshot(Accessor{}, map[string]interface{}{
+		"counter": 0,
+		"items":   []interface{}{},
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				root, version := snapshot.Load()
+
+				items, err := Get(root, "items")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				if uint64(len(items.([]interface{}))) != version {
+					t.Errorf("unexpected items %v for version %d", items, version)
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		if _, err := snapshot.Apply(Operation{Key: "items." + strconv.Itoa(i), Value: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wg.Wait()
+
+	if version := snapshot.Version(); version != 100 {
+		t.Fatalf("unexpected version %d", version)
+	}
+}
@@ -0,0 +1,134 @@
+package dotnotation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// streamNode is a node in the tree of properties requested by GetFromReader.
+type streamNode struct {
+	children map[string]*streamNode
+	// keys lists the requested keys that end at this node
+	keys []string
+}
+
+// GetFromReader reads a single JSON value from r, returning the values at each of keys, by key, using an
+// encoding/json.Decoder to only decode the requested values, skipping any other subtrees, which avoids decoding the
+// entire value. Keys that don't exist are omitted from the result. Note that r may be read beyond the end of the value.
+// The parser is used for keys, and the getter is only used for keys that are within another requested key.
+func (p Accessor) GetFromReader(r io.Reader, keys ...string) (map[string]interface{}, error) {
+	root := &streamNode{}
+
+	for _, key := range keys {
+		properties := p.parser(key)
+
+		if len(properties) == 0 {
+			return nil, errors.New("no properties parsed from key: " + key)
+		}
+
+		node := root
+		for _, property := range properties {
+			child, ok := node.children[property]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[string]*streamNode)
+				}
+				child = &streamNode{}
+				node.children[property] = child
+			}
+			node = child
+		}
+
+		node.keys = append(node.keys, key)
+	}
+
+	result := make(map[string]interface{}, len(keys))
+
+	if err := p.decodeStream(json.NewDecoder(r), root, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// decodeStream reads the next value from decoder, adding the values requested by node to result.
+func (p Accessor) decodeStream(decoder *json.Decoder, node *streamNode, result map[string]interface{}) error {
+	if len(node.keys) != 0 {
+		// the entire value is required, any nested keys are got from it
+		var value interface{}
+
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		p.getStream(value, node, result)
+
+		return nil
+	}
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+
+			key, ok := token.(string)
+			if !ok {
+				return fmt.Errorf("unexpected token %v", token)
+			}
+
+			if err := p.decodeStreamChild(decoder, node.children[key], result); err != nil {
+				return err
+			}
+		}
+
+	case json.Delim('['):
+		for i := 0; decoder.More(); i++ {
+			if err := p.decodeStreamChild(decoder, node.children[strconv.Itoa(i)], result); err != nil {
+				return err
+			}
+		}
+
+	default:
+		// scalar values have no properties
+		return nil
+	}
+
+	// the closing delimiter
+	_, err = decoder.Token()
+
+	return err
+}
+
+// decodeStreamChild reads the next value from decoder, as per decodeStream, or skips it if node is nil.
+func (p Accessor) decodeStreamChild(decoder *json.Decoder, node *streamNode, result map[string]interface{}) error {
+	if node == nil {
+		var skipped json.RawMessage
+		return decoder.Decode(&skipped)
+	}
+
+	return p.decodeStream(decoder, node, result)
+}
+
+// getStream adds the values requested by node, and it's descendants, to result, getting them from value.
+func (p Accessor) getStream(value interface{}, node *streamNode, result map[string]interface{}) {
+	for _, key := range node.keys {
+		result[key] = value
+	}
+
+	for property, child := range node.children {
+		if v, err := p.getter(value, property); err == nil {
+			p.getStream(v, child, result)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package dotnotation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestGetFromReader(t *testing.T) {
+	const input = `{
+		"level": "info",
+		"request": {"id": 7, "headers": {"host": "example.com"}, "body": [1, 2, {"large": true}]},
+		"tags": ["a", "b", "c"],
+		"skipped": {"nested": [[{}], "]}"]}
+	}`
+
+	type TestCase struct {
+		Keys   []string
+		Result map[string]interface{}
+		Err    string
+	}
+
+	testCases := []TestCase{
+		{
+			Keys: []string{"level", "request.id", "tags.1"},
+			Result: map[string]interface{}{
+				"level":      "info",
+				"request.id": float64(7),
+				"tags.1":     "b",
+			},
+		},
+		{
+			Keys: []string{"request.headers", "request.headers.host", "request.body.2.large"},
+			Result: map[string]interface{}{
+				"request.headers":      map[string]interface{}{"host": "example.com"},
+				"request.headers.host": "example.com",
+				"request.body.2.large": true,
+			},
+		},
+		{
+			Keys:   []string{"missing", "level.one", "tags.3", "request.id.one"},
+			Result: map[string]interface{}{},
+		},
+		{
+			Keys: []string{""},
+			Err:  "no properties parsed from key: ",
+		},
+	}
+
+	accessor := Accessor{
+		Parser: func(key string) []string {
+			if key == "" {
+				return nil
+			}
+			return DefaultParser(key)
+		},
+	}
+
+	for i, testCase := range testCases {
+		result, err := accessor.GetFromReader(strings.NewReader(input), testCase.Keys...)
+
+		if (err == nil) != (testCase.Err == "") || (err != nil && err.Error() != testCase.Err) {
+			t.Errorf("test case %d expected error %q, got %v", i, testCase.Err, err)
+		}
+
+		if diff := deep.Equal(testCase.Result, result); diff != nil {
+			t.Errorf("test case %d unexpected result: %s", i, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestGetFromReader_invalid(t *testing.T) {
+	for i, input := range []string{``, `{"one": `, `{"one": [1, }`, `{"two": {"three"}}`} {
+		if _, err := GetFromReader(strings.NewReader(input), "one.two"); err == nil {
+			t.Errorf("test case %d expected an error", i)
+		}
+	}
+}
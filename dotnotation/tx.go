@@ -0,0 +1,139 @@
+package dotnotation
+
+import (
+	"errors"
+)
+
+// Tx records changes made within Accessor.Batch, so that they may be rolled back.
+type Tx struct {
+	accessor Accessor
+	target   interface{}
+	undo     []func()
+	err      error
+}
+
+// Batch calls fn with a Tx, for making multiple changes to target, that are rolled back, in reverse order, if any
+// change fails, or fn returns an error, in which case the first error is returned. Changes made to target, other
+// than via the Tx, are not rolled back. Rolling back slices restores their previous elements, and other containers
+// have the previous value of each changed key restored, or the key deleted, if it did not exist.
+func (p Accessor) Batch(target interface{}, fn func(tx *Tx) error) error {
+	tx := &Tx{
+		accessor: p,
+		target:   target,
+	}
+
+	err := fn(tx)
+
+	if tx.err != nil {
+		err = tx.err
+	}
+
+	if err != nil {
+		tx.rollback()
+		return err
+	}
+
+	return nil
+}
+
+// Get returns the value at key, as per Accessor.Get, including any changes made so far.
+func (tx *Tx) Get(key string) (interface{}, error) {
+	return tx.accessor.Get(tx.target, key)
+}
+
+// Set sets the value at key, as per Accessor.Set. Any failure will cause the batch to be rolled back, and all
+// subsequent changes to fail.
+func (tx *Tx) Set(key string, value interface{}) error {
+	return tx.apply(key, func(properties []string) error {
+		return tx.accessor.set(tx.target, properties, value)
+	})
+}
+
+// Delete removes the value at key, as per Accessor.Delete. Any failure will cause the batch to be rolled back, and
+// all subsequent changes to fail.
+func (tx *Tx) Delete(key string) error {
+	return tx.apply(key, func(properties []string) error {
+		return tx.accessor.delete(tx.target, properties)
+	})
+}
+
+func (tx *Tx) apply(key string, fn func(properties []string) error) error {
+	if tx.err != nil {
+		return tx.err
+	}
+
+	properties := tx.accessor.parser(key)
+
+	if len(properties) == 0 {
+		tx.err = errors.New("no properties parsed from key: " + key)
+		return tx.err
+	}
+
+	undo, err := tx.record(properties)
+
+	if err == nil {
+		err = fn(properties)
+	}
+
+	if err != nil {
+		tx.err = err
+		return err
+	}
+
+	tx.undo = append(tx.undo, undo)
+
+	return nil
+}
+
+// record returns a function that will restore the current state of the value at properties.
+func (tx *Tx) record(properties []string) (func(), error) {
+	parent := properties[:len(properties)-1]
+
+	container, err := tx.accessor.get(tx.target, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := container.(type) {
+	case []interface{}:
+		saved := append(make([]interface{}, 0, len(v)), v...)
+
+		if len(parent) == 0 {
+			// a slice without a parent can only be modified in place
+			return func() {
+				copy(v, saved)
+			}, nil
+		}
+
+		return func() {
+			_ = tx.accessor.set(tx.target, parent, saved)
+		}, nil
+
+	case *[]interface{}:
+		saved := append(make([]interface{}, 0, len(*v)), *v...)
+
+		return func() {
+			*v = saved
+		}, nil
+	}
+
+	previous, err := tx.accessor.get(tx.target, properties)
+
+	if err != nil {
+		return func() {
+			_ = tx.accessor.delete(tx.target, properties)
+		}, nil
+	}
+
+	return func() {
+		_ = tx.accessor.set(tx.target, properties, previous)
+	}, nil
+}
+
+func (tx *Tx) rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+
+	tx.undo = nil
+}
@@ -0,0 +1,123 @@
+package dotnotation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestAccessor_Batch(t *testing.T) {
+	newTarget := func() map[string]interface{} {
+		return map[string]interface{}{
+			"a": map[string]interface{}{"b": 1, "c": 2},
+			"items": []interface{}{
+				1,
+				2,
+			},
+		}
+	}
+
+	testCases := []struct {
+		name    string
+		fn      func(tx *Tx) error
+		err     string
+		outcome interface{}
+	}{
+		{
+			name: "success",
+			fn: func(tx *Tx) error {
+				if err := tx.Set("a.b", 3); err != nil {
+					return err
+				}
+				if err := tx.Delete("a.c"); err != nil {
+					return err
+				}
+				if err := tx.Set("items.2", 3); err != nil {
+					return err
+				}
+				return tx.Delete("items.0")
+			},
+			outcome: map[string]interface{}{
+				"a":     map[string]interface{}{"b": 3},
+				"items": []interface{}{2, 3},
+			},
+		},
+		{
+			name: "step failure",
+			fn: func(tx *Tx) error {
+				_ = tx.Set("a.b", 3)
+				_ = tx.Set("a.d", 4)
+				_ = tx.Delete("a.c")
+				_ = tx.Set("items.2", 3)
+				_ = tx.Delete("items.0")
+				_ = tx.Set("missing.key", 1)
+				// ignored, after the failure
+				_ = tx.Set("a.e", 5)
+				return nil
+			},
+			err:     "cannot get non-existent property 'missing' on a map",
+			outcome: newTarget(),
+		},
+		{
+			name: "callback failure",
+			fn: func(tx *Tx) error {
+				_ = tx.Set("a", 1)
+				_ = tx.Delete("items.1")
+				return errors.New("some error")
+			},
+			err:     "some error",
+			outcome: newTarget(),
+		},
+	}
+
+	for _, testCase := range testCases {
+		target := newTarget()
+
+		err := Accessor{}.Batch(target, testCase.fn)
+
+		if (err == nil && testCase.err != "") || (err != nil && err.Error() != testCase.err) {
+			t.Errorf("%s failed: unexpected error %v", testCase.name, err)
+		}
+
+		if diff := deep.Equal(testCase.outcome, target); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", testCase.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestAccessor_Batch_rootSlice(t *testing.T) {
+	target := []interface{}{1, 2}
+	pointer := &[]interface{}{1, 2}
+
+	err := Accessor{}.Batch(target, func(tx *Tx) error {
+		_ = tx.Set("0", 3)
+		return errors.New("some error")
+	})
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	err = Accessor{}.Batch(pointer, func(tx *Tx) error {
+		_ = tx.Set("2", 3)
+		_ = tx.Delete("0")
+		if value, err := tx.Get("0"); err != nil || value != 2 {
+			t.Errorf("unexpected value %v / error %v", value, err)
+		}
+		return errors.New("some error")
+	})
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if diff := deep.Equal([]interface{}{1, 2}, target); diff != nil {
+		t.Errorf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+
+	if diff := deep.Equal(&[]interface{}{1, 2}, pointer); diff != nil {
+		t.Errorf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}
@@ -0,0 +1,119 @@
+package dotnotation
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Wildcard is the property that matches any property of a container, in a pattern passed to Document.Watch.
+const Wildcard = "*"
+
+// Change describes a change to the value at a single path, where Key is the concrete path, with properties joined
+// by ".", and Old or New are nil if the path did not exist, before or after the change, respectively.
+type Change struct {
+	Key string
+	Old interface{}
+	New interface{}
+}
+
+type watcher struct {
+	pattern []string
+	fn      func(change Change)
+}
+
+// Watch calls fn for every change to the value at any path matching pattern, e.g. "db.*.host", made via the
+// Document, including changes to ancestors that replace the value, and changes to it's descendants. The Wildcard
+// property only matches the properties of the types supported by DefaultGetter, and PropertyLister. Watchers are
+// called after each change, without holding the lock, one at a time, in the order the changes were made, so a change
+// may be delivered by the goroutine that made a later change, or after the call that made it returns, if another
+// goroutine is already delivering changes, including any made by the watchers themselves. The returned function stops
+// watching.
+func (d *Document) Watch(pattern string, fn func(change Change)) (cancel func()) {
+	w := &watcher{
+		pattern: d.accessor.parser(pattern),
+		fn:      fn,
+	}
+
+	d.mutex.Lock()
+	d.watchers = append(d.watchers, w)
+	d.mutex.Unlock()
+
+	return func() {
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+
+		for i, other := range d.watchers {
+			if other == w {
+				d.watchers = append(d.watchers[:i:i], d.watchers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// overlaps returns true if a change at properties might change a path matching the pattern.
+func (w *watcher) overlaps(properties []string) bool {
+	for i := 0; i < len(properties) && i < len(w.pattern); i++ {
+		if w.pattern[i] != Wildcard && w.pattern[i] != properties[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// expand returns all values within target matching pattern, by their concrete path, as deep copies if clone is true.
+func (p Accessor) expand(target interface{}, pattern []string, clone bool) map[string]interface{} {
+	result := make(map[string]interface{})
+	p.expandInto(target, pattern, nil, clone, result)
+	return result
+}
+
+func (p Accessor) expandInto(target interface{}, pattern []string, path []string, clone bool, result map[string]interface{}) {
+	if len(pattern) == 0 {
+		if clone {
+			target = deepCopy(target)
+		}
+		result[strings.Join(path, ".")] = target
+		return
+	}
+
+	properties := pattern[:1]
+
+	if pattern[0] == Wildcard {
+		properties, _ = listProperties(target)
+	}
+
+	for _, property := range properties {
+		value, err := p.getter(target, property)
+		if err != nil {
+			continue
+		}
+
+		p.expandInto(value, pattern[1:], append(path[:len(path):len(path)], property), clone, result)
+	}
+}
+
+// diffValues returns a Change for every path with a different value in before and after, sorted by path.
+func diffValues(before map[string]interface{}, after map[string]interface{}) []Change {
+	var changes []Change
+
+	for key, old := range before {
+		if value, ok := after[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes = append(changes, Change{Key: key, Old: old, New: value})
+		}
+	}
+
+	for key, value := range after {
+		if _, ok := before[key]; !ok {
+			changes = append(changes, Change{Key: key, New: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
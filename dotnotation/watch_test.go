@@ -0,0 +1,214 @@
+package dotnotation
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestDocument_Watch(t *testing.T) {
+	document := NewDocument(Accessor{}, map[string]interface{}{
+		"db": map[string]interface{}{
+			"primary": map[string]interface{}{"host": "one", "port": 1},
+			"replica": map[string]interface{}{"host": "two", "port": 2},
+		},
+		"cache": map[string]interface{}{"host": "three"},
+	})
+
+	var changes []Change
+
+	cancel := document.Watch("db.*.host", func(change Change) {
+		changes = append(changes, change)
+	})
+
+	steps := []struct {
+		name    string
+		fn      func() error
+		changes []Change
+	}{
+		{
+			name: "set matching",
+			fn: func() error {
+				return document.Set("db.primary.host", "four")
+			},
+			changes: []Change{
+				{Key: "db.primary.host", Old: "one", New: "four"},
+			},
+		},
+		{
+			name: "set same value",
+			fn: func() error {
+				return document.Set("db.primary.host", "four")
+			},
+			changes: nil,
+		},
+		{
+			name: "set sibling",
+			fn: func() error {
+				return document.Set("db.primary.port", 3)
+			},
+			changes: nil,
+		},
+		{
+			name: "set unrelated",
+			fn: func() error {
+				return document.Set("cache.host", "five")
+			},
+			changes: nil,
+		},
+		{
+			name: "set ancestor",
+			fn: func() error {
+				return document.Set("db", map[string]interface{}{
+					"primary": map[string]interface{}{"host": "four"},
+					"backup":  map[string]interface{}{"host": "six"},
+				})
+			},
+			changes: []Change{
+				{Key: "db.backup.host", New: "six"},
+				{Key: "db.replica.host", Old: "two"},
+			},
+		},
+		{
+			name: "delete",
+			fn: func() error {
+				return document.Delete("db.backup")
+			},
+			changes: []Change{
+				{Key: "db.backup.host", Old: "six"},
+			},
+		},
+		{
+			name: "merge",
+			fn: func() error {
+				return document.Merge("db", map[string]interface{}{
+					"primary": map[string]interface{}{"host": "seven"},
+				})
+			},
+			changes: []Change{
+				{Key: "db.primary.host", Old: "four", New: "seven"},
+			},
+		},
+		{
+			name: "update",
+			fn: func() error {
+				return document.Update(func(root interface{}) error {
+					return Set(root, "db.primary.host", "eight")
+				})
+			},
+			changes: []Change{
+				{Key: "db.primary.host", Old: "seven", New: "eight"},
+			},
+		},
+		{
+			name: "cancelled",
+			fn: func() error {
+				cancel()
+				return document.Set("db.primary.host", "nine")
+			},
+			changes: nil,
+		},
+	}
+
+	for _, step := range steps {
+		changes = nil
+
+		if err := step.fn(); err != nil {
+			t.Fatalf("%s failed: unexpected error %v", step.name, err)
+		}
+
+		if diff := deep.Equal(step.changes, changes); diff != nil {
+			t.Errorf("%s failed: unexpected diff %v", step.name, strings.Join(diff, ", "))
+		}
+	}
+}
+
+func TestDocument_Watch_reentrant(t *testing.T) {
+	document := NewDocument(Accessor{}, map[string]interface{}{"one": 1})
+
+	var value interface{}
+
+	document.Watch("one", func(change Change) {
+		// watchers are called without holding the lock
+		value, _ = document.Get("one")
+	})
+
+	if err := document.Set("one", 2); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if value != 2 {
+		t.Fatalf("unexpected value %v", value)
+	}
+}
+
+func TestDocument_Watch_ordering(t *testing.T) {
+	document := NewDocument(Accessor{}, map[string]interface{}{"n": 0})
+
+	var (
+		last       interface{}
+		delivering int32
+	)
+
+	document.Watch("n", func(change Change) {
+		if atomic.AddInt32(&delivering, 1) != 1 {
+			t.Error("expected changes to be delivered one at a time")
+		}
+		last = change.New
+		atomic.AddInt32(&delivering, -1)
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 1; i <= 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := document.Set("n", i); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+
+	value, err := document.Get("n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last != value {
+		t.Fatalf("expected the last change %v to match the value %v", last, value)
+	}
+}
+
+func TestDocument_Watch_reentrantSet(t *testing.T) {
+	document := NewDocument(Accessor{}, map[string]interface{}{"one": 1, "two": 1})
+
+	var changes []Change
+
+	document.Watch("*", func(change Change) {
+		changes = append(changes, change)
+		if change.Key == "one" {
+			if err := document.Set("two", change.New); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	if err := document.Set("one", 2); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	expected := []Change{
+		{Key: "one", Old: 1, New: 2},
+		{Key: "two", Old: 1, New: 2},
+	}
+
+	if diff := deep.Equal(expected, changes); diff != nil {
+		t.Fatalf("unexpected diff %v", strings.Join(diff, ", "))
+	}
+}